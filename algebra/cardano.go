@@ -2,6 +2,7 @@ package algebra
 
 import (
 	"math"
+	"math/cmplx"
 	"sort"
 )
 
@@ -77,3 +78,112 @@ func Shengjin(a, b, c, d float64) (ans []float64) {
 		return []float64{-(b + 2*K*i) / (3 * a), (K*(i+j) - b) / (3 * a), (K*(i-j) - b) / (3 * a)}
 	}
 }
+
+// 费拉里公式解一元四次方程，返回去重并排序后的实数根
+func Ferrari(a, b, c, d, e float64) (ans []float64) {
+	if a == 0 {
+		return Cardano(b, c, d, e)
+	}
+	z := FerrariComplex(a, b, c, d, e)
+	for _, x := range z {
+		if imag(x) == 0 {
+			ans = append(ans, real(x))
+		}
+	}
+	sort.Float64s(ans)
+	// FerrariComplex已将重根合并为相同的值
+	j := 0
+	for i, x := range ans {
+		if i == 0 || x != ans[j-1] {
+			ans[j], j = x, j+1
+		}
+	}
+	return ans[:j]
+}
+
+// 费拉里公式解一元四次方程，返回全部四个复数根（重根重复给出且数值相同），实根的虚部为零
+func FerrariComplex(a, b, c, d, e float64) []complex128 {
+	if a == 0 {
+		return nil
+	}
+	b, c, d, e = b/a, c/a, d/a, e/a
+	k := b / 4
+	p := c - 6*k*k
+	q := d - 2*c*k + 8*k*k*k
+	r := e - d*k + c*k*k - 3*k*k*k*k
+	var y []complex128
+	if math.Abs(q) <= 1e-14*(math.Abs(d)+math.Abs(c*k)+math.Abs(k*k*k)+1e-300) {
+		// 双二次方程
+		for _, u := range quadratic(1, p, r) {
+			s := cmplx.Sqrt(u)
+			y = append(y, s, -s)
+		}
+	} else {
+		// 预解三次方程的最大实根必为正
+		m := Cardano(1, p, p*p/4-r, -q*q/8)
+		s := math.Sqrt(2 * m[len(m)-1])
+		t := p/2 + m[len(m)-1]
+		y = append(quadratic(1, s, t-q/(2*s)), quadratic(1, -s, t+q/(2*s))...)
+	}
+	f := Unary{e, d, c, b, 1}
+	g := f.Reduce()
+	for i := range y {
+		x := y[i] - complex(k, 0)
+		// 牛顿迭代修正舍入误差，仅在残差变小时采纳
+		for n := 0; n < 3; n++ {
+			v := f.Complex().Compute(x)
+			w := g.Complex().Compute(x)
+			if w == 0 {
+				break
+			}
+			z := x - v/w
+			if imag(x) == 0 {
+				z = complex(real(z), 0)
+			}
+			if cmplx.Abs(f.Complex().Compute(z)) >= cmplx.Abs(v) {
+				break
+			}
+			x = z
+		}
+		if imag(x) == 0 {
+			x = complex(real(x), 0)
+		}
+		y[i] = x
+	}
+	// 重根会被公式分裂为相距约为机器精度平方根的两个根，经验证后合并；
+	// 由一对共轭根合并而成的实重根的虚部只剩舍入误差
+	y = mergeMultiple(f.Complex(), y)
+	for i, x := range y {
+		if imag(x) == 0 || math.Abs(imag(x)) > 1e-7*(1+cmplx.Abs(x)) {
+			continue
+		}
+		for j := i + 1; j < len(y); j++ {
+			if y[j] == x {
+				y[i], y[j] = complex(real(x), 0), complex(real(x), 0)
+			}
+		}
+	}
+	sortComplex(y)
+	return y
+}
+
+// 求解一元二次方程的两个复数根，判别式在舍入误差内为零时按重根处理
+func quadratic(a, b, c float64) []complex128 {
+	D := b*b - 4*a*c
+	if math.Abs(D) <= 1e-12*(b*b+math.Abs(4*a*c)) {
+		D = 0
+	}
+	if D >= 0 {
+		k := math.Sqrt(D)
+		if b < 0 {
+			k = -k
+		}
+		u := -(b + k) / 2
+		if u == 0 {
+			return []complex128{0, 0}
+		}
+		return []complex128{complex(u/a, 0), complex(c/u, 0)}
+	}
+	k := math.Sqrt(-D)
+	return []complex128{complex(-b/(2*a), -k/(2*a)), complex(-b/(2*a), k/(2*a))}
+}
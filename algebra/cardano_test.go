@@ -0,0 +1,47 @@
+package algebra
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveUnaryDoubleRoots(t *testing.T) {
+	cases := [][]float64{
+		{-3, 1, 1, 2},
+		{1, 1, 2, 2},
+		{1, 1, 1, 2},
+		{1, 1, 2, 3, 4},
+		{1, 1, 3, 3, 3, 5},
+	}
+	for _, r := range cases {
+		// 去重后的根
+		var want []float64
+		for i, x := range r {
+			if i == 0 || x != r[i-1] {
+				want = append(want, x)
+			}
+		}
+		got := SolveUnary(fromRoots(r...))
+		if len(got) != len(want) {
+			t.Errorf("roots %v: got %v", r, got)
+			continue
+		}
+		for i, x := range want {
+			if math.Abs(got[i]-x) > 1e-9 {
+				t.Errorf("roots %v: got %v", r, got)
+				break
+			}
+		}
+	}
+}
+
+func TestFerrariComplexDoubleRoot(t *testing.T) {
+	p := fromRoots(-3, 1, 1, 2)
+	z := FerrariComplex(p[4], p[3], p[2], p[1], p[0])
+	want := []complex128{-3, 1, 1, 2}
+	for i, x := range want {
+		if z[i] != x {
+			t.Fatalf("got %v, want %v", z, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package algebra
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// 复系数一元多项式
+type ComplexUnary []complex128
+
+// 转换为复系数多项式
+func (this Unary) Complex() ComplexUnary {
+	that := make(ComplexUnary, len(this))
+	for i, e := range this {
+		that[i] = complex(e, 0)
+	}
+	return that
+}
+
+// 返回多项式的次数
+func (this ComplexUnary) Order() int {
+	return len(this) - 1
+}
+
+// 计算多项式函数的值, a[0]+a[1]*x+a[2]*x^2+...+a[n]*x^n
+func (this ComplexUnary) Compute(x complex128) (y complex128) {
+	for i := len(this) - 1; i >= 0; i-- {
+		y = y*x + this[i]
+	}
+	return y
+}
+
+// 求微分多项式
+func (this ComplexUnary) Reduce() ComplexUnary {
+	if len(this) <= 1 {
+		return ComplexUnary{0}
+	}
+	that := make(ComplexUnary, len(this)-1)
+	for i, e := range this[1:] {
+		that[i] = complex(float64(i+1), 0) * e
+	}
+	return that
+}
+
+// 多项式相加
+func (this ComplexUnary) Add(that ComplexUnary) ComplexUnary {
+	x, y := len(this), len(that)
+	if x < y {
+		this, x, that, y = that, y, this, x
+	}
+	i, r := 0, make(ComplexUnary, x)
+	for ; i < y; i++ {
+		r[i] = this[i] + that[i]
+	}
+	for ; i < x; i++ {
+		r[i] = this[i]
+	}
+	return r
+}
+
+// 多项式相减
+func (this ComplexUnary) Sub(that ComplexUnary) ComplexUnary {
+	x, y := len(this), len(that)
+	l := x
+	if y > l {
+		l = y
+	}
+	r := make(ComplexUnary, l)
+	copy(r, this)
+	for i := 0; i < y; i++ {
+		r[i] -= that[i]
+	}
+	return r
+}
+
+// 多项式相乘
+func (this ComplexUnary) Mul(that ComplexUnary) ComplexUnary {
+	x, y := len(this), len(that)
+	r := make(ComplexUnary, x+y-1)
+	for i := 0; i < x; i++ {
+		for j := 0; j < y; j++ {
+			r[i+j] += this[i] * that[j]
+		}
+	}
+	return r
+}
+
+// 多项式相除
+func (this ComplexUnary) Div(that ComplexUnary) ComplexUnary {
+	x, y := len(this), len(that)
+	if y > x {
+		return ComplexUnary{0}
+	}
+	u := make(ComplexUnary, x)
+	r := make(ComplexUnary, x-y+1)
+	copy(u, this)
+	for i, j := x-y, x-1; i >= 0; i, j = i-1, j-1 {
+		k := u[j] / that[y-1]
+		for a, b := j, y-1; b >= 0; a, b = a-1, b-1 {
+			u[a] -= k * that[b]
+		}
+		r[i] = k
+	}
+	return r
+}
+
+// 用Aberth法求复系数多项式的全部复数根，重根按重数重复给出，结果按实部、虚部排序。
+func SolveComplex(p ComplexUnary) []complex128 {
+	i, j := 0, len(p)-1
+	for j >= 0 && p[j] == 0 {
+		j--
+	}
+	if j <= 0 {
+		return nil
+	}
+	for p[i] == 0 {
+		i++
+	}
+	s := make([]complex128, i, j)
+	p = p[i : j+1]
+	n := len(p) - 1
+	if n > 0 {
+		s = append(s, aberth(p)...)
+	}
+	sortComplex(s)
+	return s
+}
+
+// 求实系数多项式的全部复数根，重根按重数重复给出；共轭根的虚部在舍入误差内为零时视为实根。
+// 先用Aberth法求原多项式的根，再把经验证确为重根的近似根簇合并为同一个值
+func SolveUnaryComplex(p Unary) []complex128 {
+	s := mergeMultiple(p.Complex(), SolveComplex(p.Complex()))
+	for i, z := range s {
+		if math.Abs(imag(z)) <= 1e-7*(1+cmplx.Abs(z)) {
+			s[i] = complex(real(z), 0)
+		}
+	}
+	sortComplex(s)
+	return s
+}
+
+// 聚类时近似根间距的上限（相对于1+|z|）；k重根受舍入误差扰动后散开的距离约为机器精度的1/k次方
+const clusterGap = 1e-2
+
+// 计算p(x)时舍入误差的上界：2n*epsilon*Σ|p[k]|*|x|^k
+func evalBound(p ComplexUnary, x complex128) float64 {
+	var m float64
+	r := cmplx.Abs(x)
+	for i := len(p) - 1; i >= 0; i-- {
+		m = m*r + cmplx.Abs(p[i])
+	}
+	return 2 * float64(len(p)) * epsilon * m
+}
+
+// 将确认为重根的近似根簇合并为同一个值（就地修改z并返回z）：按间距把近似根聚成簇，
+// 对簇中的k个根，从它们的均值出发用牛顿法求p的k-1阶导数的根c，若p及其前k-1阶导数在c处的值
+// 都不超过求值的舍入误差界，则c为k重根；k从簇的大小开始递减，只差一点的相异根不会被合并
+func mergeMultiple(p ComplexUnary, z []complex128) []complex128 {
+	if len(z) < 2 {
+		return z
+	}
+	d := []ComplexUnary{p}
+	for k := 1; k <= len(z); k++ {
+		d = append(d, d[k-1].Reduce())
+	}
+	used := make([]bool, len(z))
+	for i := range z {
+		if used[i] {
+			continue
+		}
+		c := []int{i}
+		used[i] = true
+		for a := 0; a < len(c); a++ {
+			for j := range z {
+				if !used[j] && cmplx.Abs(z[j]-z[c[a]]) <= clusterGap*(1+cmplx.Abs(z[j])) {
+					used[j] = true
+					c = append(c, j)
+				}
+			}
+		}
+		for len(c) >= 2 && mergeCluster(d, z, &c) {
+			// 簇中可能还有其他重根
+		}
+	}
+	return z
+}
+
+// 在簇c中寻找一个重根并合并，成功时从c中去掉已合并的根
+func mergeCluster(d []ComplexUnary, z []complex128, c *[]int) bool {
+	for k := len(*c); k >= 2; k-- {
+		for _, seed := range *c {
+			// 与seed最近的k个根
+			t := append([]int(nil), (*c)...)
+			sort.Slice(t, func(a, b int) bool {
+				return cmplx.Abs(z[t[a]]-z[seed]) < cmplx.Abs(z[t[b]]-z[seed])
+			})
+			var m complex128
+			for _, j := range t[:k] {
+				m += z[j]
+			}
+			m /= complex(float64(k), 0)
+			x := newtonRoot(d[k-1], d[k], m)
+			if cmplx.Abs(x-m) > clusterGap*(1+cmplx.Abs(m)) {
+				continue
+			}
+			ok := true
+			for j := 0; j < k && ok; j++ {
+				ok = cmplx.Abs(d[j].Compute(x)) <= evalBound(d[j], x)
+			}
+			if !ok {
+				continue
+			}
+			for _, j := range t[:k] {
+				z[j] = x
+			}
+			*c = t[k:]
+			return true
+		}
+	}
+	return false
+}
+
+// 从x出发用牛顿法求p的根，q为p的导数；返回迭代过程中残差最小的点
+func newtonRoot(p, q ComplexUnary, x complex128) complex128 {
+	best, r := x, cmplx.Abs(p.Compute(x))
+	for n := 0; n < 50 && r != 0; n++ {
+		w := q.Compute(x)
+		if w == 0 {
+			break
+		}
+		x -= p.Compute(x) / w
+		if e := cmplx.Abs(p.Compute(x)); e < r {
+			best, r = x, e
+		} else {
+			break
+		}
+	}
+	return best
+}
+
+// Aberth迭代，要求p的常数项与最高次项均不为零
+func aberth(p ComplexUnary) []complex128 {
+	n := len(p) - 1
+	if n == 1 {
+		return []complex128{-p[0] / p[1]}
+	}
+	q := p.Reduce()
+	// 初始点均匀分布于以根的几何平均模为半径的圆上，错开角度以避开实轴对称
+	r := math.Pow(cmplx.Abs(p[0]/p[n]), 1/float64(n))
+	z := make([]complex128, n)
+	for k := range z {
+		z[k] = cmplx.Rect(r, 2*math.Pi*float64(k)/float64(n)+0.4)
+	}
+	for t := 0; t < 500; t++ {
+		done := true
+		for k := range z {
+			y := p.Compute(z[k])
+			if y == 0 {
+				continue
+			}
+			w := y / q.Compute(z[k])
+			var s complex128
+			for i := range z {
+				if i != k {
+					s += 1 / (z[k] - z[i])
+				}
+			}
+			d := w / (1 - w*s)
+			if cmplx.IsNaN(d) || cmplx.IsInf(d) {
+				continue
+			}
+			z[k] -= d
+			if cmplx.Abs(d) > 1e-15*(1+cmplx.Abs(z[k])) {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+	}
+	return z
+}
+
+// 按实部、虚部的顺序排序复数
+func sortComplex(s []complex128) {
+	sort.Slice(s, func(a, b int) bool {
+		if real(s[a]) != real(s[b]) {
+			return real(s[a]) < real(s[b])
+		}
+		return imag(s[a]) < imag(s[b])
+	})
+}
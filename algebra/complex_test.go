@@ -0,0 +1,41 @@
+package algebra
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+// 以给定的根构造首一多项式
+func fromRoots(r ...float64) Unary {
+	p := Unary{1}
+	for _, x := range r {
+		p = p.Mul(Unary{-x, 1})
+	}
+	return p
+}
+
+func TestSolveUnaryComplexMultiple(t *testing.T) {
+	cases := []struct {
+		roots []float64
+		tol   float64
+	}{
+		{[]float64{1, 1, 1, 2}, 1e-12},
+		{[]float64{-2, -2, -2, -2, 5}, 1e-12},
+		// 相距很近的相异根不能被当作重根合并
+		{[]float64{1, 1.00001, 3}, 1e-9},
+		{[]float64{1, 1.000001, 3}, 1e-8},
+	}
+	for _, c := range cases {
+		z := SolveUnaryComplex(fromRoots(c.roots...))
+		if len(z) != len(c.roots) {
+			t.Errorf("roots %v: got %v", c.roots, z)
+			continue
+		}
+		for i, x := range c.roots {
+			if imag(z[i]) != 0 || cmplx.Abs(z[i]-complex(x, 0)) > c.tol {
+				t.Errorf("roots %v: got %v", c.roots, z)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package algebra
+
+import "math"
+
+// 中心差分求f在x处的导数，h为步长，h<=0时自动选取
+func CentralDiff(f func(float64) float64, x, h float64) float64 {
+	if h <= 0 {
+		h = math.Cbrt(epsilon) * math.Max(math.Abs(x), 1)
+	}
+	return (f(x+h) - f(x-h)) / (2 * h)
+}
+
+// 中心差分结合理查森外推（Ridders方法）求f在x处的导数及其误差估计，h为初始步长，h<=0时自动选取。
+// 中心差分的截断误差只含h的偶次幂，步长逐次减半，第k次外推消去h^(2k)项；
+// 取相邻两次对角线外推值之差最小者作为结果，差值比最小值大两个数量级（舍入误差占优）时停止
+func Richardson(f func(float64) float64, x, h float64) (float64, float64) {
+	if h <= 0 {
+		h = 0.1 * math.Max(math.Abs(x), 1)
+	}
+	diff := func(h float64) float64 {
+		return (f(x+h) - f(x-h)) / (2 * h)
+	}
+	prev := []float64{diff(h)}
+	ans, err := prev[0], math.Inf(+1)
+	for n := 1; n < 16; n++ {
+		h /= 2
+		row := make([]float64, n+1)
+		row[0] = diff(h)
+		for k, p := 1, 4.0; k <= n; k, p = k+1, p*4 {
+			row[k] = row[k-1] + (row[k-1]-prev[k-1])/(p-1)
+		}
+		e := math.Abs(row[n] - prev[n-1])
+		if e < err {
+			ans, err = row[n], e
+		} else if e > 100*err {
+			break
+		}
+		prev = row
+	}
+	return ans, err
+}
+
+// 切线法求一元方程解，导数用中心差分近似；f为求解函数，求解区间为[p,q]，x为迭代起始点。
+func TangentDiff(f func(float64) float64, p, q float64, x float64) (float64, error) {
+	return Tangent(f, func(x float64) float64 { return CentralDiff(f, x, 0) }, p, q, x)
+}
+
+// 同Monotone，导数用中心差分近似
+func MonotoneDiff(f func(float64) float64) (float64, error) {
+	return Monotone(f, func(x float64) float64 { return CentralDiff(f, x, 0) })
+}
+
+// 切线法求一元方程解，导数由对偶数自动微分精确求出；求解区间为[p,q]，x为迭代起始点。
+func TangentDual(f func(Dual) Dual, p, q float64, x float64) (float64, error) {
+	v, k := DualFunc(f)
+	return Tangent(v, k, p, q, x)
+}
+
+// 同Monotone，导数由对偶数自动微分精确求出
+func MonotoneDual(f func(Dual) Dual) (float64, error) {
+	v, k := DualFunc(f)
+	return Monotone(v, k)
+}
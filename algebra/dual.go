@@ -0,0 +1,138 @@
+package algebra
+
+import "math"
+
+// 对偶数Val+Der*ε（ε^2=0），用于前向模式自动微分：Der随运算传播导数
+type Dual struct {
+	Val, Der float64
+}
+
+// 自变量，导数为1
+func Variable(x float64) Dual {
+	return Dual{x, 1}
+}
+
+// 常量，导数为0
+func Constant(c float64) Dual {
+	return Dual{c, 0}
+}
+
+// 求f在x处的函数值与导数
+func DualDerivative(f func(Dual) Dual, x float64) (float64, float64) {
+	y := f(Variable(x))
+	return y.Val, y.Der
+}
+
+// 将f拆分为函数及其导函数
+func DualFunc(f func(Dual) Dual) (func(float64) float64, func(float64) float64) {
+	return func(x float64) float64 {
+			return f(Constant(x)).Val
+		}, func(x float64) float64 {
+			return f(Variable(x)).Der
+		}
+}
+
+// 对偶数相加
+func (this Dual) Add(that Dual) Dual {
+	return Dual{this.Val + that.Val, this.Der + that.Der}
+}
+
+// 对偶数相减
+func (this Dual) Sub(that Dual) Dual {
+	return Dual{this.Val - that.Val, this.Der - that.Der}
+}
+
+// 对偶数相乘
+func (this Dual) Mul(that Dual) Dual {
+	return Dual{this.Val * that.Val, this.Der*that.Val + this.Val*that.Der}
+}
+
+// 对偶数相除
+func (this Dual) Div(that Dual) Dual {
+	return Dual{this.Val / that.Val, (this.Der*that.Val - this.Val*that.Der) / (that.Val * that.Val)}
+}
+
+// 取相反数
+func (this Dual) Neg() Dual {
+	return Dual{-this.Val, -this.Der}
+}
+
+// 乘以一个系数
+func (this Dual) ScalarMul(k float64) Dual {
+	return Dual{this.Val * k, this.Der * k}
+}
+
+// 实数次幂
+func (this Dual) Pow(k float64) Dual {
+	return Dual{math.Pow(this.Val, k), k * math.Pow(this.Val, k-1) * this.Der}
+}
+
+// 平方根
+func (this Dual) Sqrt() Dual {
+	s := math.Sqrt(this.Val)
+	return Dual{s, this.Der / (2 * s)}
+}
+
+// 自然指数
+func (this Dual) Exp() Dual {
+	e := math.Exp(this.Val)
+	return Dual{e, e * this.Der}
+}
+
+// 自然对数
+func (this Dual) Log() Dual {
+	return Dual{math.Log(this.Val), this.Der / this.Val}
+}
+
+// 正弦
+func (this Dual) Sin() Dual {
+	return Dual{math.Sin(this.Val), math.Cos(this.Val) * this.Der}
+}
+
+// 余弦
+func (this Dual) Cos() Dual {
+	return Dual{math.Cos(this.Val), -math.Sin(this.Val) * this.Der}
+}
+
+// 正切
+func (this Dual) Tan() Dual {
+	t := math.Tan(this.Val)
+	return Dual{t, (1 + t*t) * this.Der}
+}
+
+// 反正切
+func (this Dual) Atan() Dual {
+	return Dual{math.Atan(this.Val), this.Der / (1 + this.Val*this.Val)}
+}
+
+// 双曲正弦
+func (this Dual) Sinh() Dual {
+	return Dual{math.Sinh(this.Val), math.Cosh(this.Val) * this.Der}
+}
+
+// 双曲余弦
+func (this Dual) Cosh() Dual {
+	return Dual{math.Cosh(this.Val), math.Sinh(this.Val) * this.Der}
+}
+
+// 双曲正切
+func (this Dual) Tanh() Dual {
+	t := math.Tanh(this.Val)
+	return Dual{t, (1 - t*t) * this.Der}
+}
+
+// 绝对值，Val为零时导数取Der本身
+func (this Dual) Abs() Dual {
+	if this.Val < 0 {
+		return this.Neg()
+	}
+	return this
+}
+
+// 以对偶数计算多项式的值及导数
+func (this Unary) ComputeDual(x Dual) (y Dual) {
+	for i := len(this) - 1; i >= 0; i-- {
+		y = y.Mul(x).Add(Constant(this[i]))
+	}
+	return y
+}
@@ -0,0 +1,366 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+var ErrNotSymmetric = errors.New("Matrix not symmetric")
+
+// 实对称矩阵的特征分解，A=V*diag(Values)*V'，Values降序排列，V的第j列为Values[j]对应的单位特征向量
+type SymEigen struct {
+	Values  []float64
+	Vectors Matrix
+}
+
+// 检查是否为方阵，返回阶数
+func (this Matrix) square() (int, error) {
+	n := this.Rows()
+	if n == 0 {
+		return 0, ErrDimension
+	}
+	for i := range this {
+		if len(this[i]) != n {
+			return 0, ErrDimension
+		}
+	}
+	return n, nil
+}
+
+// 用循环Jacobi旋转法求实对称矩阵的全部特征值与特征向量
+func (this Matrix) SymEigen() (*SymEigen, error) {
+	n, err := this.square()
+	if err != nil {
+		return nil, err
+	}
+	a := this.Copy()
+	var norm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			norm = math.Hypot(norm, a[i][j])
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(a[i][j]-a[j][i]) > 1e-10*norm {
+				return nil, ErrNotSymmetric
+			}
+		}
+	}
+	v := Identity(n)
+	for sweep := 0; ; sweep++ {
+		var off float64
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				off = math.Hypot(off, a[p][q])
+			}
+		}
+		if off <= epsilon*norm {
+			break
+		}
+		if sweep == 60 {
+			return nil, &ConvergenceError{"Jacobi", sweep}
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				z := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(z) + math.Hypot(1, z))
+				if z < 0 {
+					t = -t
+				}
+				c := 1 / math.Hypot(1, t)
+				s := c * t
+				for k := 0; k < n; k++ {
+					x, y := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*x-s*y, s*x+c*y
+				}
+				for k := 0; k < n; k++ {
+					x, y := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*x-s*y, s*x+c*y
+				}
+				a[p][q], a[q][p] = 0, 0
+				for k := 0; k < n; k++ {
+					x, y := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*x-s*y, s*x+c*y
+				}
+			}
+		}
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return a[idx[i]][idx[i]] > a[idx[j]][idx[j]]
+	})
+	ans := &SymEigen{Values: make([]float64, n), Vectors: NewMatrix(n, n)}
+	for j, k := range idx {
+		ans.Values[j] = a[k][k]
+		for i := 0; i < n; i++ {
+			ans.Vectors[i][j] = v[i][k]
+		}
+	}
+	return ans, nil
+}
+
+// 平衡矩阵：对每个下标i，把第i列乘以2^k、第i行除以2^k（相似变换，特征值不变），
+// k取使该行与该列非对角元的1-范数之和最小的整数；反复进行直到各行列的范数之和不再明显减小
+func balance(a Matrix) {
+	n := len(a)
+	for sweep, changed := 0, true; changed && sweep < 20; sweep++ {
+		changed = false
+		for i := 0; i < n; i++ {
+			var r, c float64
+			for j := 0; j < n; j++ {
+				if j != i {
+					r += math.Abs(a[i][j])
+					c += math.Abs(a[j][i])
+				}
+			}
+			if r == 0 || c == 0 {
+				continue
+			}
+			// c*f+r/f在f=sqrt(r/c)处最小，f取最接近的2的整数次幂以免引入舍入误差
+			k := int(math.Round(math.Log2(r/c) / 2))
+			if k == 0 {
+				continue
+			}
+			f := math.Ldexp(1, k)
+			if c*f+r/f >= 0.99*(c+r) {
+				continue
+			}
+			changed = true
+			for j := 0; j < n; j++ {
+				a[j][i] *= f
+				a[i][j] /= f
+			}
+		}
+	}
+}
+
+// 构造豪斯霍尔德变换I-beta*v*v'，使其作用于x后除第一个分量外均为零；v[0]=1
+func householderVector(x []float64) ([]float64, float64) {
+	v := make([]float64, len(x))
+	v[0] = 1
+	var s float64
+	for _, e := range x[1:] {
+		s += e * e
+	}
+	if s == 0 {
+		return v, 0
+	}
+	mu := math.Sqrt(x[0]*x[0] + s)
+	// 选择不会相消的表达式
+	var v0 float64
+	if x[0] <= 0 {
+		v0 = x[0] - mu
+	} else {
+		v0 = -s / (x[0] + mu)
+	}
+	for i := 1; i < len(x); i++ {
+		v[i] = x[i] / v0
+	}
+	return v, 2 * v0 * v0 / (s + v0*v0)
+}
+
+// 左乘变换：第r0行起的len(v)行，列号在[c0,c1]内
+func reflectRows(a Matrix, v []float64, beta float64, r0, c0, c1 int) {
+	if beta == 0 {
+		return
+	}
+	for j := c0; j <= c1; j++ {
+		var s float64
+		for i, e := range v {
+			s += e * a[r0+i][j]
+		}
+		s *= beta
+		for i, e := range v {
+			a[r0+i][j] -= s * e
+		}
+	}
+}
+
+// 右乘变换：第c0列起的len(v)列，行号在[r0,r1]内
+func reflectCols(a Matrix, v []float64, beta float64, c0, r0, r1 int) {
+	if beta == 0 {
+		return
+	}
+	for i := r0; i <= r1; i++ {
+		var s float64
+		for j, e := range v {
+			s += e * a[i][c0+j]
+		}
+		s *= beta
+		for j, e := range v {
+			a[i][c0+j] -= s * e
+		}
+	}
+}
+
+// 用豪斯霍尔德变换将矩阵正交相似变换为上海森伯格矩阵
+func hessenberg(a Matrix) {
+	n := len(a)
+	x := make([]float64, n)
+	for k := 0; k < n-2; k++ {
+		for i := k + 1; i < n; i++ {
+			x[i-k-1] = a[i][k]
+		}
+		v, beta := householderVector(x[:n-k-1])
+		reflectRows(a, v, beta, k+1, k, n-1)
+		reflectCols(a, v, beta, k+1, 0, n-1)
+		for i := k + 2; i < n; i++ {
+			a[i][k] = 0
+		}
+	}
+}
+
+// 二阶矩阵[[a,b],[c,d]]的两个特征值
+func eigen2(a, b, c, d float64) (complex128, complex128) {
+	m, p := (a+d)/2, (a-d)/2
+	q := p*p + b*c
+	if q < 0 {
+		w := math.Sqrt(-q)
+		return complex(m, -w), complex(m, w)
+	}
+	x := m + math.Copysign(math.Sqrt(q), p)
+	y := m - math.Copysign(math.Sqrt(q), p)
+	// 用行列式求绝对值较小的根，避免相消
+	if x != 0 {
+		y = (a*d - b*c) / x
+	}
+	return complex(x, 0), complex(y, 0)
+}
+
+// 求一般实方阵的全部特征值（可能为复数），按实部、虚部升序排列；
+// 先平衡并用豪斯霍尔德变换化为上海森伯格矩阵，再用Francis隐式双步位移QR迭代
+func (this Matrix) Eigenvalues() ([]complex128, error) {
+	if _, err := this.square(); err != nil {
+		return nil, err
+	}
+	a := this.Copy()
+	balance(a)
+	hessenberg(a)
+	ans, err := francis(a)
+	if err != nil {
+		return nil, err
+	}
+	sortComplex(ans)
+	return ans, nil
+}
+
+// 上海森伯格矩阵的Francis隐式双步位移QR迭代，从右下角逐个分离出一阶或二阶块；a会被破坏
+func francis(h Matrix) ([]complex128, error) {
+	n := len(h)
+	ans := make([]complex128, 0, n)
+	var norm float64
+	for i := range h {
+		for _, e := range h[i] {
+			norm = math.Hypot(norm, e)
+		}
+	}
+	for hi, iter := n-1, 0; hi >= 0; {
+		// 次对角元相对于相邻对角元可以忽略时置零，l为右下角不可约块的起点
+		l := hi
+		for ; l > 0; l-- {
+			s := math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[l][l-1]) <= epsilon*s {
+				h[l][l-1] = 0
+				break
+			}
+		}
+		switch l {
+		case hi:
+			ans = append(ans, complex(h[hi][hi], 0))
+			hi, iter = hi-1, 0
+			continue
+		case hi - 1:
+			x, y := eigen2(h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi])
+			ans = append(ans, x, y)
+			hi, iter = hi-2, 0
+			continue
+		}
+		if iter >= 30*n {
+			return nil, &ConvergenceError{"Francis QR", iter}
+		}
+		iter++
+		// 位移取右下角二阶块的两个特征值，以其和s与积t表示；长时间不收敛时改用特殊位移打破循环
+		m := hi
+		s := h[m-1][m-1] + h[m][m]
+		t := h[m-1][m-1]*h[m][m] - h[m-1][m]*h[m][m-1]
+		if iter%10 == 0 {
+			w := math.Abs(h[m][m-1]) + math.Abs(h[m-1][m-2])
+			s = 2 * h[m][m]
+			t = h[m][m]*h[m][m] + w*w
+		}
+		// (H^2-sH+tI)e1的前三个分量
+		x := h[l][l]*h[l][l] + h[l][l+1]*h[l+1][l] - s*h[l][l] + t
+		y := h[l+1][l] * (h[l][l] + h[l+1][l+1] - s)
+		z := h[l+1][l] * h[l+2][l+1]
+		for k := l; k <= m-2; k++ {
+			v, beta := householderVector([]float64{x, y, z})
+			q := k - 1
+			if q < l {
+				q = l
+			}
+			reflectRows(h, v, beta, k, q, m)
+			r := k + 3
+			if r > m {
+				r = m
+			}
+			reflectCols(h, v, beta, k, l, r)
+			x, y = h[k+1][k], h[k+2][k]
+			if k < m-2 {
+				z = h[k+3][k]
+			}
+		}
+		v, beta := householderVector([]float64{x, y})
+		reflectRows(h, v, beta, m-1, m-2, m)
+		reflectCols(h, v, beta, m-1, l, m)
+	}
+	return ans, nil
+}
+
+// 首一化后的友矩阵，其特征值即多项式的根；次数低于1时返回nil
+func (this Unary) Companion() Matrix {
+	p := this.Trim(0)
+	n := len(p) - 1
+	if n < 1 {
+		return nil
+	}
+	c := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		c[0][i] = -p[n-1-i] / p[n]
+		if i > 0 {
+			c[i][i-1] = 1
+		}
+	}
+	return c
+}
+
+// 用友矩阵的特征值求多项式的全部复数根，重根按重数重复给出，按实部、虚部升序排列；
+// 与SolveUnaryComplex一样，虚部在舍入误差内为零时视为实根
+func SolveUnaryEigen(p Unary) ([]complex128, error) {
+	c := p.Companion()
+	if c == nil {
+		return nil, nil
+	}
+	s, err := c.Eigenvalues()
+	if err != nil {
+		return nil, err
+	}
+	for i, z := range s {
+		if math.Abs(imag(z)) <= 1e-7*(1+cmplx.Abs(z)) {
+			s[i] = complex(real(z), 0)
+		}
+	}
+	sortComplex(s)
+	return s, nil
+}
@@ -0,0 +1,265 @@
+package algebra
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 多项式转换为字符串的参数，零值字段使用默认值
+type FormatOptions struct {
+	Var     string // 自变量名，默认x
+	Prec    int    // 系数的有效数字位数，<=0时使用能精确还原的最短表示
+	Unicode bool   // 是否用上标数字表示次数，如x²
+}
+
+var superscripts = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+// 解析时允许的最高次数，避免"x^2000000000"之类的输入分配巨量内存
+const maxParseOrder = 1 << 16
+
+// 上标数字的值，不是上标数字时返回-1
+func superscript(r rune) int {
+	for i, e := range superscripts {
+		if e == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// 按降幂排列的字符串表示，如"3x^3 - 2x + 1"
+func (this Unary) String() string {
+	return this.Text(nil)
+}
+
+// 按参数转换为降幂排列的字符串，系数为零的项省略，零多项式为"0"
+func (this Unary) Text(opt *FormatOptions) string {
+	var o FormatOptions
+	if opt != nil {
+		o = *opt
+	}
+	if o.Var == "" {
+		o.Var = "x"
+	}
+	if o.Prec <= 0 {
+		o.Prec = -1
+	}
+	var sb strings.Builder
+	for i := len(this) - 1; i >= 0; i-- {
+		c := this[i]
+		if c == 0 {
+			continue
+		}
+		neg := c < 0
+		if neg {
+			c = -c
+		}
+		switch {
+		case sb.Len() == 0 && neg:
+			sb.WriteString("-")
+		case sb.Len() != 0 && neg:
+			sb.WriteString(" - ")
+		case sb.Len() != 0:
+			sb.WriteString(" + ")
+		}
+		s := strconv.FormatFloat(c, 'g', o.Prec, 64)
+		if i == 0 || s != "1" {
+			sb.WriteString(s)
+		}
+		if i == 0 {
+			continue
+		}
+		sb.WriteString(o.Var)
+		if i == 1 {
+			continue
+		}
+		n := strconv.Itoa(i)
+		if o.Unicode {
+			for _, r := range n {
+				sb.WriteRune(superscripts[r-'0'])
+			}
+		} else {
+			sb.WriteString("^" + n)
+		}
+	}
+	if sb.Len() == 0 {
+		return "0"
+	}
+	return sb.String()
+}
+
+// 解析多项式，接受String/Text输出的格式：各项可按任意顺序排列，同次项会合并，
+// 系数与自变量之间可用"*"连接，次数可写作x^n或上标数字且不超过maxParseOrder，全式只能有一个自变量名
+func ParseUnary(s string) (Unary, error) {
+	r := []rune(s)
+	i, name := 0, ""
+	var ans Unary
+	skip := func() {
+		for i < len(r) && unicode.IsSpace(r[i]) {
+			i++
+		}
+	}
+	digits := func() int {
+		j := i
+		for i < len(r) && r[i] >= '0' && r[i] <= '9' {
+			i++
+		}
+		return i - j
+	}
+	for first := true; ; first = false {
+		skip()
+		if i == len(r) {
+			if first {
+				return nil, errors.New("Empty polynomial")
+			}
+			break
+		}
+		// 符号
+		sign := 1.0
+		switch r[i] {
+		case '+', '-':
+			if r[i] == '-' {
+				sign = -1
+			}
+			i++
+			skip()
+		default:
+			if !first {
+				return nil, errors.New("Expect '+' or '-' at offset " + strconv.Itoa(i))
+			}
+		}
+		// 系数
+		coef, hasCoef, star := 1.0, false, false
+		if j := i; i < len(r) && (r[i] >= '0' && r[i] <= '9' || r[i] == '.') {
+			n := digits()
+			if i < len(r) && r[i] == '.' {
+				i++
+				n += digits()
+			}
+			if n == 0 {
+				return nil, errors.New("Invalid number at offset " + strconv.Itoa(j))
+			}
+			if i < len(r) && (r[i] == 'e' || r[i] == 'E') {
+				k := i + 1
+				if k < len(r) && (r[k] == '+' || r[k] == '-') {
+					k++
+				}
+				if k < len(r) && r[k] >= '0' && r[k] <= '9' {
+					i = k
+					digits()
+				}
+			}
+			v, err := strconv.ParseFloat(string(r[j:i]), 64)
+			if err != nil {
+				return nil, err
+			}
+			coef, hasCoef = v, true
+			skip()
+			if i < len(r) && r[i] == '*' {
+				i, star = i+1, true
+				skip()
+			}
+		}
+		// 自变量及次数
+		order := 0
+		if i < len(r) && (unicode.IsLetter(r[i]) || r[i] == '_') {
+			j := i
+			for i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]) || r[i] == '_') {
+				i++
+			}
+			if v := string(r[j:i]); name == "" {
+				name = v
+			} else if v != name {
+				return nil, errors.New("Multiple variables: " + name + ", " + v)
+			}
+			order = 1
+			skip()
+			if i < len(r) && r[i] == '^' {
+				i++
+				skip()
+				j := i
+				if digits() == 0 {
+					return nil, errors.New("Invalid exponent at offset " + strconv.Itoa(j))
+				}
+				n, err := strconv.Atoi(string(r[j:i]))
+				if err != nil || n > maxParseOrder {
+					return nil, errors.New("Exponent too large at offset " + strconv.Itoa(j))
+				}
+				order = n
+			} else if i < len(r) && superscript(r[i]) >= 0 {
+				j := i
+				order = 0
+				for i < len(r) && superscript(r[i]) >= 0 {
+					if order = order*10 + superscript(r[i]); order > maxParseOrder {
+						return nil, errors.New("Exponent too large at offset " + strconv.Itoa(j))
+					}
+					i++
+				}
+			}
+		} else if star {
+			// "*"之后必须是自变量
+			return nil, errors.New("Expect variable at offset " + strconv.Itoa(i))
+		} else if !hasCoef {
+			return nil, errors.New("Expect term at offset " + strconv.Itoa(i))
+		}
+		for len(ans) <= order {
+			ans = append(ans, 0)
+		}
+		ans[order] += sign * coef
+	}
+	return ans, nil
+}
+
+// 系数中有NaN或无穷时返回错误，这样的多项式编码后无法再解析
+func (this Unary) checkFinite() error {
+	for _, c := range this {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return errors.New("Non-finite coefficient")
+		}
+	}
+	return nil
+}
+
+// 实现encoding.TextMarshaler，系数使用能精确还原的最短表示；系数含NaN或无穷时返回错误
+func (this Unary) MarshalText() ([]byte, error) {
+	if err := this.checkFinite(); err != nil {
+		return nil, err
+	}
+	return []byte(this.Text(nil)), nil
+}
+
+// 实现encoding.TextUnmarshaler
+func (this *Unary) UnmarshalText(text []byte) error {
+	p, err := ParseUnary(string(text))
+	if err != nil {
+		return err
+	}
+	*this = p
+	return nil
+}
+
+// 编码为JSON字符串，如"3x^3 - 2x + 1"；系数含NaN或无穷时返回错误
+func (this Unary) MarshalJSON() ([]byte, error) {
+	if err := this.checkFinite(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(this.Text(nil))
+}
+
+// 从JSON字符串解析；也接受按升幂排列的系数数组
+func (this *Unary) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return this.UnmarshalText([]byte(s))
+	}
+	var a []float64
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*this = a
+	return nil
+}
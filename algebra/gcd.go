@@ -0,0 +1,85 @@
+package algebra
+
+// 去掉相对于最大系数可以忽略的高次项：|a[i]|<=tol*max|a[j]|，全部为零时返回Unary{0}
+func (this Unary) Trim(tol float64) Unary {
+	return trimUnary(this, tol*maxAbs(this))
+}
+
+// 求首一化的最大公因式，余式的系数相对于被除式均不超过tol时视为整除；两者均为零时返回Unary{0}
+func (this Unary) GCD(that Unary, tol float64) Unary {
+	p, q := this.Trim(0), that.Trim(0)
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	if maxAbs(q) == 0 {
+		if maxAbs(p) == 0 {
+			return Unary{0}
+		}
+		return p.ScalarMul(1 / p[len(p)-1])
+	}
+	p = p.ScalarMul(1 / maxAbs(p))
+	q = q.ScalarMul(1 / maxAbs(q))
+	for len(q) > 1 {
+		r := trimUnary(p.Mod(q), tol)
+		m := maxAbs(r)
+		if m == 0 {
+			break
+		}
+		p, q = q, r.ScalarMul(1/m)
+	}
+	if len(q) == 1 {
+		return Unary{1}
+	}
+	return q.ScalarMul(1 / q[len(q)-1])
+}
+
+// 无平方因式分解（Yun算法）：返回f[0],f[1],...，使this=c*f[0]*f[1]^2*f[2]^3*...，各f[i]首一且两两互素
+func (this Unary) SquareFree(tol float64) []Unary {
+	p := this.Trim(0)
+	if len(p) <= 1 {
+		return nil
+	}
+	p = p.ScalarMul(1 / p[len(p)-1])
+	q := p.Reduce()
+	a := p.GCD(q, tol)
+	b, c := p.Div(a), q.Div(a)
+	var ans []Unary
+	for len(b) > 1 {
+		d := trimUnary(c.Sub(b.Reduce()), tol*maxAbs(c))
+		a = b.GCD(d, tol)
+		ans = append(ans, a)
+		b, c = b.Div(a), d.Div(a)
+	}
+	// 去掉末尾的平凡因式
+	for len(ans) > 0 && len(ans[len(ans)-1]) == 1 {
+		ans = ans[:len(ans)-1]
+	}
+	return ans
+}
+
+// 去掉重因式，返回首一且与原多项式有相同根（重数均为1）的多项式
+func (this Unary) SquareFreePart(tol float64) Unary {
+	p := this.Trim(0)
+	if len(p) <= 1 {
+		return Unary{1}
+	}
+	g := p.GCD(p.Reduce(), tol)
+	r := p.Div(g)
+	return r.ScalarMul(1 / r[len(r)-1])
+}
+
+// 多项式复合，返回this(that(x))
+func (this Unary) Compose(that Unary) Unary {
+	if len(this) == 0 {
+		return Unary{0}
+	}
+	if len(that) == 0 {
+		that = Unary{0}
+	}
+	r := Unary{this[len(this)-1]}
+	for i := len(this) - 2; i >= 0; i-- {
+		r = r.Mul(that)
+		r[0] += this[i]
+	}
+	return r
+}
@@ -0,0 +1,165 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+)
+
+// 取x、y中较短者的长度，并检查节点是否互不相同
+func interpNodes(x, y []float64) (int, error) {
+	l := len(x)
+	if len(y) < l {
+		l = len(y)
+	}
+	if l == 0 {
+		return 0, errors.New("Data-set too small")
+	}
+	for i := 0; i < l; i++ {
+		for j := i + 1; j < l; j++ {
+			if x[i] == x[j] {
+				return 0, errors.New("Duplicate interpolation nodes")
+			}
+		}
+	}
+	return l, nil
+}
+
+// 用牛顿均差法求经过所有点(x[i],y[i])的插值多项式
+func NewtonInterpolate(x, y []float64) (Unary, error) {
+	l, err := interpNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	c := make([]float64, l)
+	copy(c, y[:l])
+	for k := 1; k < l; k++ {
+		for i := l - 1; i >= k; i-- {
+			c[i] = (c[i] - c[i-1]) / (x[i] - x[i-k])
+		}
+	}
+	p := Unary{c[l-1]}
+	for k := l - 2; k >= 0; k-- {
+		p = p.Mul(Unary{-x[k], 1})
+		p[0] += c[k]
+	}
+	return p, nil
+}
+
+// 重心形式的拉格朗日插值
+type Lagrange struct {
+	x, y, w []float64
+}
+
+// 由点集(x[i],y[i])创建拉格朗日插值
+func NewLagrange(x, y []float64) (*Lagrange, error) {
+	l, err := interpNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	w := make([]float64, l)
+	for i := 0; i < l; i++ {
+		w[i] = 1
+		for j := 0; j < l; j++ {
+			if j != i {
+				w[i] /= x[i] - x[j]
+			}
+		}
+	}
+	this := &Lagrange{x: make([]float64, l), y: make([]float64, l), w: w}
+	copy(this.x, x)
+	copy(this.y, y)
+	return this, nil
+}
+
+// 用重心公式计算插值多项式在t处的值，无需展开为系数形式
+func (this *Lagrange) Compute(t float64) float64 {
+	var p, q float64
+	for i, x := range this.x {
+		if t == x {
+			return this.y[i]
+		}
+		k := this.w[i] / (t - x)
+		p += k * this.y[i]
+		q += k
+	}
+	return p / q
+}
+
+// 展开为系数形式的多项式
+func (this *Lagrange) Unary() Unary {
+	l := len(this.x)
+	// 先求出全部节点的乘积多项式，再逐个除去一次因式
+	all := Unary{1}
+	for _, x := range this.x {
+		all = all.Mul(Unary{-x, 1})
+	}
+	r := make(Unary, l)
+	for i, x := range this.x {
+		r = r.Add(all.Div(Unary{-x, 1}).ScalarMul(this.w[i] * this.y[i]))
+	}
+	return r
+}
+
+// 区间[a,b]上的n个切比雪夫节点（第一类切比雪夫多项式的零点），按升序排列
+func ChebyshevNodes(n int, a, b float64) []float64 {
+	s := make([]float64, n)
+	for k := 0; k < n; k++ {
+		t := -math.Cos((float64(k) + 0.5) * math.Pi / float64(n))
+		s[k] = (a+b)/2 + (b-a)/2*t
+	}
+	return s
+}
+
+// 区间[A,B]上以切比雪夫多项式为基的展开：C[0]*T0(t)+C[1]*T1(t)+...，t=(2x-A-B)/(B-A)
+type Chebyshev struct {
+	A, B float64
+	C    []float64
+}
+
+// 用n个切比雪夫节点上的函数值构造f在[a,b]上的n-1次切比雪夫逼近
+func ChebyshevApprox(f func(float64) float64, a, b float64, n int) (*Chebyshev, error) {
+	if n <= 0 {
+		return nil, errors.New("Illegal input n")
+	}
+	if a >= b {
+		return nil, errors.New("Illegal interval")
+	}
+	v := make([]float64, n)
+	for k := 0; k < n; k++ {
+		t := math.Cos((float64(k) + 0.5) * math.Pi / float64(n))
+		v[k] = f((a+b)/2 + (b-a)/2*t)
+	}
+	c := make([]float64, n)
+	for j := 0; j < n; j++ {
+		var s float64
+		for k := 0; k < n; k++ {
+			s += v[k] * math.Cos(float64(j)*(float64(k)+0.5)*math.Pi/float64(n))
+		}
+		c[j] = 2 * s / float64(n)
+	}
+	c[0] /= 2
+	return &Chebyshev{A: a, B: b, C: c}, nil
+}
+
+// 用Clenshaw算法计算展开式在x处的值
+func (this *Chebyshev) Compute(x float64) float64 {
+	t := (2*x - this.A - this.B) / (this.B - this.A)
+	var p, q float64
+	for j := len(this.C) - 1; j >= 1; j-- {
+		p, q = 2*t*p-q+this.C[j], p
+	}
+	return t*p - q + this.C[0]
+}
+
+// 展开为x的系数形式的多项式
+func (this *Chebyshev) Unary() Unary {
+	n := len(this.C)
+	r := Unary{0}
+	t0, t1 := Unary{1}, Unary{0, 1}
+	for j := 0; j < n; j++ {
+		r = r.Add(t0.ScalarMul(this.C[j]))
+		t0, t1 = t1, t1.Mul(Unary{0, 2}).Sub(t0)
+	}
+	d := this.B - this.A
+	return r.Compose(Unary{-(this.A + this.B) / d, 2 / d})
+}
@@ -0,0 +1,274 @@
+package algebra
+
+import "math"
+
+// 迭代法解稀疏线性方程组的参数，零值字段使用默认值；
+// 当残差满足||b-A*x||<=AbsTol+RelTol*||b||（2-范数）时停止迭代
+type IterOptions struct {
+	Options                // RelTol默认1e-10，MaxIter默认为未知数个数的两倍且不少于100
+	Precond Preconditioner // 预条件子，nil表示不使用
+	X0      []float64      // 迭代起始点，nil表示零向量
+	Restart int            // GMRES的重启周期，默认30
+}
+
+// 迭代法解线性方程组的结果
+type IterResult struct {
+	X          []float64 // 近似解
+	Residual   float64   // 残差的2-范数||b-A*x||
+	Iterations int       // 已使用的迭代次数
+}
+
+// 内积
+func dot(x, y []float64) float64 {
+	var s float64
+	for i, e := range x {
+		s += e * y[i]
+	}
+	return s
+}
+
+// 检查维数、填充默认值并计算初始残差
+func (this *IterOptions) prepare(A *CSR, b []float64) (IterOptions, *IterResult, []float64, error) {
+	var o IterOptions
+	if this != nil {
+		o = *this
+	}
+	n := A.Rows
+	if A.Cols != n || len(b) != n || o.X0 != nil && len(o.X0) != n {
+		return o, nil, nil, ErrDimension
+	}
+	if o.RelTol <= 0 {
+		o.RelTol = 1e-10
+	}
+	if o.MaxIter <= 0 {
+		o.MaxIter = 2 * n
+		if o.MaxIter < 100 {
+			o.MaxIter = 100
+		}
+	}
+	if o.Restart <= 0 {
+		o.Restart = 30
+	}
+	o.Options = o.Options.normalize()
+	res := &IterResult{X: make([]float64, n)}
+	if o.X0 != nil {
+		copy(res.X, o.X0)
+	}
+	r := make([]float64, n)
+	A.mul(r, res.X)
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+	res.Residual = norm2(r)
+	return o, res, r, nil
+}
+
+// 应用预条件子，z=M^-1*r
+func (this *IterOptions) precond(z, r []float64) {
+	if this.Precond == nil {
+		copy(z, r)
+	} else {
+		this.Precond.Solve(z, r)
+	}
+}
+
+// 预条件共轭梯度法解A*x=b，要求A与预条件子均对称正定
+func ConjugateGradient(A *CSR, b []float64, opt *IterOptions) (*IterResult, error) {
+	o, res, r, err := opt.prepare(A, b)
+	if err != nil {
+		return nil, err
+	}
+	tol := o.AbsTol + o.RelTol*norm2(b)
+	n := len(b)
+	z, p, q := make([]float64, n), make([]float64, n), make([]float64, n)
+	o.precond(z, r)
+	copy(p, z)
+	rz := dot(r, z)
+	for res.Iterations = 0; res.Residual > tol; res.Iterations++ {
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{"CG", res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		A.mul(q, p)
+		pq := dot(p, q)
+		if pq <= 0 {
+			// A不是正定矩阵
+			return res, &ConvergenceError{"CG", res.Iterations}
+		}
+		a := rz / pq
+		for i := range r {
+			res.X[i] += a * p[i]
+			r[i] -= a * q[i]
+		}
+		res.Residual = norm2(r)
+		o.precond(z, r)
+		t := dot(r, z)
+		for i := range p {
+			p[i] = z[i] + t/rz*p[i]
+		}
+		rz = t
+	}
+	return res, nil
+}
+
+// 右预条件稳定双共轭梯度法（BiCGSTAB）解A*x=b，适用于非对称矩阵
+func BiCGSTAB(A *CSR, b []float64, opt *IterOptions) (*IterResult, error) {
+	o, res, r, err := opt.prepare(A, b)
+	if err != nil {
+		return nil, err
+	}
+	tol := o.AbsTol + o.RelTol*norm2(b)
+	n := len(b)
+	h := make([]float64, n)
+	copy(h, r)
+	p, v := make([]float64, n), make([]float64, n)
+	y, s, z, t := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for res.Iterations = 0; res.Residual > tol; res.Iterations++ {
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{"BiCGSTAB", res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		next := dot(h, r)
+		if next == 0 || omega == 0 {
+			// 算法中断
+			return res, &ConvergenceError{"BiCGSTAB", res.Iterations}
+		}
+		beta := next / rho * alpha / omega
+		rho = next
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+		o.precond(y, p)
+		A.mul(v, y)
+		alpha = rho / dot(h, v)
+		for i := range s {
+			s[i] = r[i] - alpha*v[i]
+		}
+		if norm2(s) <= tol {
+			for i := range y {
+				res.X[i] += alpha * y[i]
+			}
+			copy(r, s)
+			res.Residual = norm2(r)
+			continue
+		}
+		o.precond(z, s)
+		A.mul(t, z)
+		tt := dot(t, t)
+		if tt == 0 {
+			return res, &ConvergenceError{"BiCGSTAB", res.Iterations}
+		}
+		omega = dot(t, s) / tt
+		for i := range r {
+			res.X[i] += alpha*y[i] + omega*z[i]
+			r[i] = s[i] - omega*t[i]
+		}
+		res.Residual = norm2(r)
+	}
+	return res, nil
+}
+
+// 右预条件重启GMRES法解A*x=b，适用于非对称矩阵，每Restart次迭代重启一次；
+// 迭代次数按内层迭代计
+func GMRES(A *CSR, b []float64, opt *IterOptions) (*IterResult, error) {
+	o, res, r, err := opt.prepare(A, b)
+	if err != nil {
+		return nil, err
+	}
+	tol := o.AbsTol + o.RelTol*norm2(b)
+	n, m := len(b), o.Restart
+	if m > n {
+		m = n
+	}
+	V := NewMatrix(m+1, n) // Krylov子空间的正交基
+	H := NewMatrix(m+1, m) // 海森伯格矩阵，用Givens旋转化为上三角
+	cs, sn, g := make([]float64, m), make([]float64, m), make([]float64, m+1)
+	z, w := make([]float64, n), make([]float64, n)
+	for res.Iterations = 0; res.Residual > tol; {
+		beta := res.Residual
+		for i := range r {
+			V[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+		k := 0
+		for k < m && math.Abs(g[k]) > tol {
+			if res.Iterations >= o.MaxIter {
+				break
+			}
+			if err := o.cancelled(); err != nil {
+				return res, err
+			}
+			res.Iterations++
+			o.precond(z, V[k])
+			A.mul(w, z)
+			// 改进的Gram-Schmidt正交化
+			for j := 0; j <= k; j++ {
+				H[j][k] = dot(w, V[j])
+				for i := range w {
+					w[i] -= H[j][k] * V[j][i]
+				}
+			}
+			H[k+1][k] = norm2(w)
+			if H[k+1][k] != 0 {
+				for i := range w {
+					V[k+1][i] = w[i] / H[k+1][k]
+				}
+			}
+			for j := 0; j < k; j++ {
+				p, q := H[j][k], H[j+1][k]
+				H[j][k], H[j+1][k] = cs[j]*p+sn[j]*q, -sn[j]*p+cs[j]*q
+			}
+			// 新的Givens旋转消去H[k+1][k]
+			d := math.Hypot(H[k][k], H[k+1][k])
+			if d == 0 {
+				break
+			}
+			cs[k], sn[k] = H[k][k]/d, H[k+1][k]/d
+			H[k][k], H[k+1][k] = d, 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] *= cs[k]
+			k++
+		}
+		if k == 0 {
+			return res, &ConvergenceError{"GMRES", res.Iterations}
+		}
+		// 回代求系数y，x+=M^-1*V*y
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			s := g[i]
+			for j := i + 1; j < k; j++ {
+				s -= H[i][j] * y[j]
+			}
+			y[i] = s / H[i][i]
+		}
+		for i := range w {
+			w[i] = 0
+		}
+		for j := 0; j < k; j++ {
+			for i := range w {
+				w[i] += y[j] * V[j][i]
+			}
+		}
+		o.precond(z, w)
+		for i := range z {
+			res.X[i] += z[i]
+		}
+		A.mul(r, res.X)
+		for i := range r {
+			r[i] = b[i] - r[i]
+		}
+		res.Residual = norm2(r)
+		if res.Residual > tol && res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{"GMRES", res.Iterations}
+		}
+	}
+	return res, nil
+}
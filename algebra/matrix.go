@@ -0,0 +1,350 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrDimension = errors.New("Mismatched dimension of matrix")
+	ErrSingular  = errors.New("Singular matrix")
+)
+
+// 稠密矩阵，按行存储
+type Matrix [][]float64
+
+// 创建r行c列的零矩阵
+func NewMatrix(r, c int) Matrix {
+	data := make([]float64, r*c)
+	m := make(Matrix, r)
+	for i := range m {
+		m[i] = data[i*c : (i+1)*c : (i+1)*c]
+	}
+	return m
+}
+
+// 创建n阶单位矩阵
+func Identity(n int) Matrix {
+	m := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// 将多元一次方程组转换为系数矩阵A和常数向量b，使方程组等价于Ax=b
+func LinearMatrix(p ...Linear) (Matrix, []float64, error) {
+	if len(p) == 0 {
+		return nil, nil, errors.New("Find no ploynomials")
+	}
+	n, l := len(p), len(p[0])
+	for i := 1; i < n; i++ {
+		if len(p[i]) != l {
+			return nil, nil, errors.New("Mismatched number of variables")
+		}
+	}
+	A, b := NewMatrix(n, l-1), make([]float64, n)
+	for i := 0; i < n; i++ {
+		copy(A[i], p[i][:l-1])
+		b[i] = -p[i][l-1]
+	}
+	return A, b, nil
+}
+
+// 返回矩阵的行数
+func (this Matrix) Rows() int {
+	return len(this)
+}
+
+// 返回矩阵的列数
+func (this Matrix) Cols() int {
+	if len(this) == 0 {
+		return 0
+	}
+	return len(this[0])
+}
+
+// 复制矩阵
+func (this Matrix) Copy() Matrix {
+	m := NewMatrix(this.Rows(), this.Cols())
+	for i := range this {
+		copy(m[i], this[i])
+	}
+	return m
+}
+
+// 转置矩阵
+func (this Matrix) Transpose() Matrix {
+	r, c := this.Rows(), this.Cols()
+	m := NewMatrix(c, r)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m[j][i] = this[i][j]
+		}
+	}
+	return m
+}
+
+// 矩阵相乘
+func (this Matrix) Mul(that Matrix) (Matrix, error) {
+	r, n, c := this.Rows(), this.Cols(), that.Cols()
+	if n != that.Rows() {
+		return nil, ErrDimension
+	}
+	m := NewMatrix(r, c)
+	for i := 0; i < r; i++ {
+		for k := 0; k < n; k++ {
+			if a := this[i][k]; a != 0 {
+				for j := 0; j < c; j++ {
+					m[i][j] += a * that[k][j]
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// 矩阵乘以向量
+func (this Matrix) MulVec(x []float64) ([]float64, error) {
+	if this.Cols() != len(x) {
+		return nil, ErrDimension
+	}
+	y := make([]float64, len(this))
+	for i, row := range this {
+		for j, e := range row {
+			y[i] += e * x[j]
+		}
+	}
+	return y, nil
+}
+
+// 矩阵的1-范数，即各列元素绝对值之和的最大值
+func (this Matrix) Norm1() float64 {
+	var s float64
+	for j, c := 0, this.Cols(); j < c; j++ {
+		var t float64
+		for i := range this {
+			t += math.Abs(this[i][j])
+		}
+		if t > s {
+			s = t
+		}
+	}
+	return s
+}
+
+// 方阵的行列式
+func (this Matrix) Det() (float64, error) {
+	lu, err := this.LU()
+	if err != nil {
+		return 0, err
+	}
+	return lu.Det(), nil
+}
+
+// 方阵的逆矩阵
+func (this Matrix) Inverse() (Matrix, error) {
+	lu, err := this.LU()
+	if err != nil {
+		return nil, err
+	}
+	return lu.Inverse()
+}
+
+// 方阵基于1-范数的条件数估计值，奇异矩阵返回正无穷
+func (this Matrix) Cond() (float64, error) {
+	lu, err := this.LU()
+	if err != nil {
+		return 0, err
+	}
+	return lu.Cond(), nil
+}
+
+// 列主元LU分解的结果，PA=LU，可重复用于求解不同的右端向量
+type LU struct {
+	lu    Matrix
+	piv   []int
+	sign  float64
+	norm1 float64
+}
+
+// 对方阵进行列主元LU分解；矩阵奇异时分解仍会完成，但求解时将返回ErrSingular
+func (this Matrix) LU() (*LU, error) {
+	n := this.Rows()
+	if n == 0 || this.Cols() != n {
+		return nil, ErrDimension
+	}
+	for i := range this {
+		if len(this[i]) != n {
+			return nil, ErrDimension
+		}
+	}
+	a := this.Copy()
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = i
+	}
+	sign := 1.0
+	for k := 0; k < n; k++ {
+		p, max := k, math.Abs(a[k][k])
+		for i := k + 1; i < n; i++ {
+			if t := math.Abs(a[i][k]); t > max {
+				p, max = i, t
+			}
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			piv[p], piv[k] = piv[k], piv[p]
+			sign = -sign
+		}
+		if a[k][k] == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			f := a[i][k] / a[k][k]
+			a[i][k] = f
+			if f != 0 {
+				for j := k + 1; j < n; j++ {
+					a[i][j] -= f * a[k][j]
+				}
+			}
+		}
+	}
+	return &LU{lu: a, piv: piv, sign: sign, norm1: this.Norm1()}, nil
+}
+
+// 判断分解的矩阵是否奇异
+func (this *LU) Singular() bool {
+	for i := range this.lu {
+		if this.lu[i][i] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// 行列式
+func (this *LU) Det() float64 {
+	d := this.sign
+	for i := range this.lu {
+		d *= this.lu[i][i]
+	}
+	return d
+}
+
+// 求解Ax=b
+func (this *LU) Solve(b []float64) ([]float64, error) {
+	n := len(this.lu)
+	if len(b) != n {
+		return nil, ErrDimension
+	}
+	if this.Singular() {
+		return nil, ErrSingular
+	}
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = b[this.piv[i]]
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			x[i] -= this.lu[i][j] * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= this.lu[i][j] * x[j]
+		}
+		x[i] /= this.lu[i][i]
+	}
+	return x, nil
+}
+
+// 求解A'x=b，A'为A的转置
+func (this *LU) SolveTranspose(b []float64) ([]float64, error) {
+	n := len(this.lu)
+	if len(b) != n {
+		return nil, ErrDimension
+	}
+	if this.Singular() {
+		return nil, ErrSingular
+	}
+	y := make([]float64, n)
+	copy(y, b)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			y[i] -= this.lu[j][i] * y[j]
+		}
+		y[i] /= this.lu[i][i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			y[i] -= this.lu[j][i] * y[j]
+		}
+	}
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[this.piv[i]] = y[i]
+	}
+	return x, nil
+}
+
+// 逆矩阵
+func (this *LU) Inverse() (Matrix, error) {
+	n := len(this.lu)
+	m := NewMatrix(n, n)
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		x, err := this.Solve(e)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			m[i][j] = x[i]
+		}
+		e[j] = 0
+	}
+	return m, nil
+}
+
+// 用Hager-Higham算法估计基于1-范数的条件数，奇异矩阵返回正无穷
+func (this *LU) Cond() float64 {
+	if this.Singular() {
+		return math.Inf(+1)
+	}
+	n := len(this.lu)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	var est float64
+	for k := 0; k < 5; k++ {
+		y, _ := this.Solve(x)
+		s := make([]float64, n)
+		est = 0
+		for i, t := range y {
+			est += math.Abs(t)
+			if t >= 0 {
+				s[i] = 1
+			} else {
+				s[i] = -1
+			}
+		}
+		z, _ := this.SolveTranspose(s)
+		j, max, dot := 0, math.Abs(z[0]), 0.0
+		for i, t := range z {
+			dot += t * x[i]
+			if math.Abs(t) > max {
+				j, max = i, math.Abs(t)
+			}
+		}
+		if max <= dot {
+			break
+		}
+		for i := range x {
+			x[i] = 0
+		}
+		x[j] = 1
+	}
+	return est * this.norm1
+}
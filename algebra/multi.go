@@ -0,0 +1,394 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 单项式各变量的次数，第i个元素为第i个变量的次数，缺少的元素视为0
+type Monomial []int
+
+// 总次数
+func (this Monomial) Degree() int {
+	var d int
+	for _, e := range this {
+		d += e
+	}
+	return d
+}
+
+// 第i个变量的次数
+func (this Monomial) at(i int) int {
+	if i < len(this) {
+		return this[i]
+	}
+	return 0
+}
+
+// 去掉末尾次数为零的变量
+func (this Monomial) trim() Monomial {
+	n := len(this)
+	for n > 0 && this[n-1] == 0 {
+		n--
+	}
+	r := make(Monomial, n)
+	copy(r, this)
+	return r
+}
+
+// 单项式的序
+type MonomialOrder int
+
+const (
+	Lex          MonomialOrder = iota // 字典序
+	GradedLex                         // 先比较总次数，再按字典序
+	GradedRevLex                      // 先比较总次数，再按反字典序
+)
+
+// 比较两个单项式，a<b返回-1，a==b返回0，a>b返回1
+func (this MonomialOrder) Compare(a, b Monomial) int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	if this != Lex {
+		if p, q := a.Degree(), b.Degree(); p != q {
+			if p < q {
+				return -1
+			}
+			return 1
+		}
+	}
+	if this == GradedRevLex {
+		// 最后一个不同的变量次数较小者为大
+		for i := n - 1; i >= 0; i-- {
+			if p, q := a.at(i), b.at(i); p != q {
+				if p > q {
+					return -1
+				}
+				return 1
+			}
+		}
+		return 0
+	}
+	for i := 0; i < n; i++ {
+		if p, q := a.at(i), b.at(i); p != q {
+			if p < q {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// 多项式的一项
+type Term struct {
+	Coef float64
+	Exp  Monomial
+}
+
+// 稀疏存储的多元多项式，如x²y+3yz表示为{{1,{2,1}},{3,{0,1,1}}}；
+// 运算结果均已合并同类项、去掉零系数项，并按GradedLex降序排列，零多项式为空切片
+type Polynomial []Term
+
+// 由各项构造多项式，合并同类项
+func NewPolynomial(t ...Term) Polynomial {
+	return Polynomial(t).normalize()
+}
+
+// 转换为多元多项式，第i个系数对应第i个变量，最后一个为常数项
+func (this Linear) Polynomial() Polynomial {
+	p := make(Polynomial, 0, len(this))
+	for i, c := range this {
+		e := make(Monomial, len(this)-1)
+		if i < len(e) {
+			e[i] = 1
+		}
+		p = append(p, Term{c, e})
+	}
+	return p.normalize()
+}
+
+// 转换为以第0个变量为自变量的多元多项式
+func (this Unary) Polynomial() Polynomial {
+	p := make(Polynomial, 0, len(this))
+	for i, c := range this {
+		p = append(p, Term{c, Monomial{i}})
+	}
+	return p.normalize()
+}
+
+// 合并同类项、去掉零系数项并排序，返回新的切片
+func (this Polynomial) normalize() Polynomial {
+	p := make(Polynomial, len(this))
+	for i, t := range this {
+		p[i] = Term{t.Coef, t.Exp.trim()}
+	}
+	p.Sort(GradedLex)
+	r := p[:0]
+	for _, t := range p {
+		if l := len(r); l > 0 && GradedLex.Compare(r[l-1].Exp, t.Exp) == 0 {
+			r[l-1].Coef += t.Coef
+		} else {
+			r = append(r, t)
+		}
+	}
+	q := r[:0]
+	for _, t := range r {
+		if t.Coef != 0 {
+			q = append(q, t)
+		}
+	}
+	if len(q) == 0 {
+		return nil
+	}
+	return q
+}
+
+// 按给定的序将各项降序排列（原地）
+func (this Polynomial) Sort(order MonomialOrder) {
+	sort.SliceStable(this, func(i, j int) bool {
+		return order.Compare(this[i].Exp, this[j].Exp) > 0
+	})
+}
+
+// 按给定的序的首项，零多项式返回零值
+func (this Polynomial) Leading(order MonomialOrder) Term {
+	var r Term
+	for i, t := range this {
+		if i == 0 || order.Compare(t.Exp, r.Exp) > 0 {
+			r = t
+		}
+	}
+	return r
+}
+
+// 出现的变量个数（最大的变量下标加一）
+func (this Polynomial) Vars() int {
+	var n int
+	for _, t := range this {
+		if e := t.Exp.trim(); len(e) > n {
+			n = len(e)
+		}
+	}
+	return n
+}
+
+// 总次数，零多项式为0
+func (this Polynomial) Degree() int {
+	var d int
+	for _, t := range this {
+		if e := t.Exp.Degree(); e > d {
+			d = e
+		}
+	}
+	return d
+}
+
+// 提供变量的值以计算多项式的值，变量个数不能少于Vars()
+func (this Polynomial) Compute(x ...float64) (float64, error) {
+	if len(x) < this.Vars() {
+		return 0, errors.New("Mismatched number of variables")
+	}
+	var y float64
+	for _, t := range this {
+		v := t.Coef
+		for i, e := range t.Exp {
+			if e != 0 {
+				v *= math.Pow(x[i], float64(e))
+			}
+		}
+		y += v
+	}
+	return y, nil
+}
+
+// 多项式相加
+func (this Polynomial) Add(that Polynomial) Polynomial {
+	p := make(Polynomial, 0, len(this)+len(that))
+	p = append(p, this...)
+	p = append(p, that...)
+	return p.normalize()
+}
+
+// 多项式相减
+func (this Polynomial) Sub(that Polynomial) Polynomial {
+	return this.Add(that.ScalarMul(-1))
+}
+
+// 乘以一个系数
+func (this Polynomial) ScalarMul(k float64) Polynomial {
+	p := make(Polynomial, len(this))
+	for i, t := range this {
+		p[i] = Term{t.Coef * k, t.Exp}
+	}
+	return p.normalize()
+}
+
+// 多项式相乘
+func (this Polynomial) Mul(that Polynomial) Polynomial {
+	p := make(Polynomial, 0, len(this)*len(that))
+	for _, a := range this {
+		for _, b := range that {
+			n := len(a.Exp)
+			if len(b.Exp) > n {
+				n = len(b.Exp)
+			}
+			e := make(Monomial, n)
+			for i := range e {
+				e[i] = a.Exp.at(i) + b.Exp.at(i)
+			}
+			p = append(p, Term{a.Coef * b.Coef, e})
+		}
+	}
+	return p.normalize()
+}
+
+// 对第i个变量求偏导数
+func (this Polynomial) Derivative(i int) Polynomial {
+	p := make(Polynomial, 0, len(this))
+	for _, t := range this {
+		if k := t.Exp.at(i); k > 0 {
+			e := make(Monomial, len(t.Exp))
+			copy(e, t.Exp)
+			e[i]--
+			p = append(p, Term{t.Coef * float64(k), e})
+		}
+	}
+	return p.normalize()
+}
+
+// 梯度，即对前n个变量的偏导数
+func (this Polynomial) Gradient(n int) []Polynomial {
+	g := make([]Polynomial, n)
+	for i := range g {
+		g[i] = this.Derivative(i)
+	}
+	return g
+}
+
+// 按GradedLex降序的字符串表示，变量依次记作x1,x2,...，如"x1^2*x2 + 3x2*x3"
+func (this Polynomial) String() string {
+	var sb strings.Builder
+	for _, t := range this.normalize() {
+		c := t.Coef
+		switch {
+		case sb.Len() == 0 && c < 0:
+			sb.WriteString("-")
+		case sb.Len() != 0 && c < 0:
+			sb.WriteString(" - ")
+		case sb.Len() != 0:
+			sb.WriteString(" + ")
+		}
+		c = math.Abs(c)
+		var v []string
+		for i, e := range t.Exp {
+			switch {
+			case e == 1:
+				v = append(v, "x"+strconv.Itoa(i+1))
+			case e > 1:
+				v = append(v, "x"+strconv.Itoa(i+1)+"^"+strconv.Itoa(e))
+			}
+		}
+		if s := strconv.FormatFloat(c, 'g', -1, 64); len(v) == 0 || s != "1" {
+			sb.WriteString(s)
+		}
+		sb.WriteString(strings.Join(v, "*"))
+	}
+	if sb.Len() == 0 {
+		return "0"
+	}
+	return sb.String()
+}
+
+// n个变量、总次数不超过d的全部单项式，按GradedLex升序排列
+func Monomials(n, d int) []Monomial {
+	var ans []Monomial
+	e := make(Monomial, n)
+	var gen func(i, left int)
+	gen = func(i, left int) {
+		if i == n {
+			m := make(Monomial, n)
+			copy(m, e)
+			ans = append(ans, m)
+			return
+		}
+		for k := 0; k <= left; k++ {
+			e[i] = k
+			gen(i+1, left-k)
+		}
+		e[i] = 0
+	}
+	gen(0, d)
+	sort.SliceStable(ans, func(i, j int) bool {
+		return GradedLex.Compare(ans[i], ans[j]) < 0
+	})
+	return ans
+}
+
+// 多元多项式拟合，x[t]为第t个样本点的各变量值，d为拟合多项式的总次数；
+// 与UnaryFit一样使用列归一化的设计矩阵的QR分解求解
+func PolynomialFit(x [][]float64, y []float64, d int) (Polynomial, error) {
+	l := len(x)
+	if len(y) < l {
+		l = len(y)
+	}
+	if d < 0 {
+		return nil, errors.New("Illegal input d")
+	}
+	if l == 0 {
+		return nil, errors.New("Data-set too small")
+	}
+	n := len(x[0])
+	for t := 1; t < l; t++ {
+		if len(x[t]) != n {
+			return nil, errors.New("Mismatched number of variables")
+		}
+	}
+	ms := Monomials(n, d)
+	m := len(ms)
+	if m > l {
+		return nil, errors.New("Data-set too small")
+	}
+	A := NewMatrix(l, m)
+	for t := 0; t < l; t++ {
+		for j, e := range ms {
+			v := 1.0
+			for i, k := range e {
+				if k != 0 {
+					v *= math.Pow(x[t][i], float64(k))
+				}
+			}
+			A[t][j] = v
+		}
+	}
+	s := make([]float64, m)
+	for j := 0; j < m; j++ {
+		for t := 0; t < l; t++ {
+			s[j] = math.Hypot(s[j], A[t][j])
+		}
+		if s[j] == 0 {
+			return nil, errors.New("Data-set too small")
+		}
+		for t := 0; t < l; t++ {
+			A[t][j] /= s[j]
+		}
+	}
+	qr, err := A.QR()
+	if err != nil {
+		return nil, err
+	}
+	c, err := qr.Solve(y[:l])
+	if err != nil {
+		return nil, errors.New("Data-set too small")
+	}
+	p := make(Polynomial, m)
+	for j, e := range ms {
+		p[j] = Term{c[j] / s[j], e}
+	}
+	return p.normalize(), nil
+}
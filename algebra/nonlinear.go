@@ -0,0 +1,172 @@
+package algebra
+
+import "math"
+
+// 非线性方程组求解的结果
+type SystemResult struct {
+	X           []float64 // 近似解
+	F           []float64 // F(X)
+	Norm        float64   // F(X)的无穷范数
+	Iterations  int       // 已使用的迭代次数
+	Evaluations int       // 调用F的次数（含差分求雅可比矩阵）
+}
+
+// 向量的无穷范数
+func normInf(x []float64) float64 {
+	var s float64
+	for _, e := range x {
+		if e = math.Abs(e); e > s {
+			s = e
+		}
+	}
+	return s
+}
+
+// 向量的2-范数
+func norm2(x []float64) float64 {
+	var s float64
+	for _, e := range x {
+		s = math.Hypot(s, e)
+	}
+	return s
+}
+
+// 用向前差分求F在x处的雅可比矩阵，y为F(x)
+func jacobian(F func([]float64) []float64, x, y []float64) Matrix {
+	n, m := len(x), len(y)
+	J := NewMatrix(m, n)
+	t := make([]float64, n)
+	copy(t, x)
+	for j := 0; j < n; j++ {
+		h := math.Sqrt(epsilon) * math.Max(math.Abs(x[j]), 1)
+		t[j] = x[j] + h
+		z := F(t)
+		for i := 0; i < m; i++ {
+			J[i][j] = (z[i] - y[i]) / h
+		}
+		t[j] = x[j]
+	}
+	return J
+}
+
+// 求解J*d=-y；J奇异或非方阵时取最小范数最小二乘解
+func newtonStep(J Matrix, y []float64) ([]float64, error) {
+	b := make([]float64, len(y))
+	for i, e := range y {
+		b[i] = -e
+	}
+	if lu, err := J.LU(); err == nil {
+		if d, err := lu.Solve(b); err == nil {
+			return d, nil
+		}
+	}
+	r, err := SolveLeastSquares(J, b)
+	if err != nil {
+		return nil, err
+	}
+	return r.X, nil
+}
+
+// 沿方向d回溯线搜索，使||F||充分下降，返回新的点、函数值及步长
+func lineSearch(F func([]float64) []float64, x, y, d []float64, res *SystemResult) ([]float64, []float64, float64) {
+	f0 := norm2(y)
+	t := make([]float64, len(x))
+	for k := 1.0; k >= 1e-10; k /= 2 {
+		for i := range x {
+			t[i] = x[i] + k*d[i]
+		}
+		z := F(t)
+		res.Evaluations++
+		if norm2(z) <= (1-1e-4*k)*f0 {
+			return t, z, k
+		}
+	}
+	return nil, nil, 0
+}
+
+// 带阻尼的牛顿-拉弗森法求解F(x)=0，J为F的雅可比矩阵（为nil时用差分近似），x为迭代起始点
+func NewtonSystem(F func([]float64) []float64, J func([]float64) Matrix, x []float64, opt *Options) (*SystemResult, error) {
+	return solveSystem("Newton", F, J, x, opt, false)
+}
+
+// 带阻尼的Broyden拟牛顿法求解F(x)=0，只在起始点计算一次雅可比矩阵（J为nil时用差分近似），之后用秩一修正
+func BroydenSystem(F func([]float64) []float64, J func([]float64) Matrix, x []float64, opt *Options) (*SystemResult, error) {
+	return solveSystem("Broyden", F, J, x, opt, true)
+}
+
+// 牛顿法与Broyden法的公共流程
+func solveSystem(name string, F func([]float64) []float64, J func([]float64) Matrix, x0 []float64, opt *Options, broyden bool) (*SystemResult, error) {
+	o := opt.normalize()
+	x := make([]float64, len(x0))
+	copy(x, x0)
+	y := F(x)
+	res := &SystemResult{Evaluations: 1}
+	jac := func(x, y []float64) Matrix {
+		if J != nil {
+			// 复制一份，Broyden修正不能改写调用者的矩阵
+			return J(x).Copy()
+		}
+		res.Evaluations += len(x)
+		return jacobian(F, x, y)
+	}
+	var B Matrix
+	for res.Iterations = 0; ; res.Iterations++ {
+		res.X, res.F, res.Norm = x, y, normInf(y)
+		if res.Norm <= o.AbsTol {
+			return res, nil
+		}
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{name, res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		fresh := B == nil || !broyden
+		if fresh {
+			B = jac(x, y)
+		}
+		d, err := newtonStep(B, y)
+		if err != nil {
+			return res, err
+		}
+		t, z, k := lineSearch(F, x, y, d, res)
+		if t == nil {
+			if !fresh {
+				// 修正矩阵失效时重新计算雅可比矩阵
+				B = nil
+				continue
+			}
+			return res, &ConvergenceError{name, res.Iterations}
+		}
+		if broyden {
+			// B += (dy - B*dx) dx' / (dx'dx)
+			var s float64
+			for i := range d {
+				d[i] *= k
+				s += d[i] * d[i]
+			}
+			if s > 0 {
+				for i := range B {
+					u := z[i] - y[i]
+					for j, e := range B[i] {
+						u -= e * d[j]
+					}
+					u /= s
+					for j := range B[i] {
+						B[i][j] += u * d[j]
+					}
+				}
+			}
+		}
+		var dx float64
+		for i := range x {
+			dx = math.Max(dx, math.Abs(t[i]-x[i]))
+		}
+		x, y = t, z
+		if k == 1 && dx <= o.tol(normInf(x)) {
+			res.Iterations++
+			res.X, res.F, res.Norm = x, y, normInf(y)
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// 正交多项式族的三项递推关系：p[k+1](x)=(A*x+B)*p[k](x)-C*p[k-1](x)，p[0]=1，p[-1]=0
+type Recurrence func(k int) (A, B, C float64)
+
+var (
+	// 勒让德多项式，(k+1)P[k+1]=(2k+1)xP[k]-kP[k-1]
+	LegendreP Recurrence = func(k int) (float64, float64, float64) {
+		t := float64(k)
+		return (2*t + 1) / (t + 1), 0, t / (t + 1)
+	}
+	// 第一类切比雪夫多项式，T[1]=x，T[k+1]=2xT[k]-T[k-1]
+	ChebyshevT Recurrence = func(k int) (float64, float64, float64) {
+		if k == 0 {
+			return 1, 0, 0
+		}
+		return 2, 0, 1
+	}
+	// 第二类切比雪夫多项式，U[k+1]=2xU[k]-U[k-1]
+	ChebyshevU Recurrence = func(k int) (float64, float64, float64) {
+		return 2, 0, 1
+	}
+	// （物理学家的）埃尔米特多项式，H[k+1]=2xH[k]-2kH[k-1]
+	HermiteH Recurrence = func(k int) (float64, float64, float64) {
+		return 2, 0, 2 * float64(k)
+	}
+	// 拉盖尔多项式，(k+1)L[k+1]=(2k+1-x)L[k]-kL[k-1]
+	LaguerreL Recurrence = func(k int) (float64, float64, float64) {
+		t := float64(k)
+		return -1 / (t + 1), (2*t + 1) / (t + 1), t / (t + 1)
+	}
+)
+
+// 雅可比多项式P(a,b)，要求a>-1且b>-1
+func JacobiP(a, b float64) Recurrence {
+	return func(k int) (float64, float64, float64) {
+		if k == 0 {
+			return (a + b + 2) / 2, (a - b) / 2, 0
+		}
+		t := float64(k)
+		s := 2*t + a + b
+		d := 2 * (t + 1) * (t + a + b + 1) * s
+		return (s + 1) * (s + 2) * s / d, (s + 1) * (a*a - b*b) / d, 2 * (t + a) * (t + b) * (s + 2) / d
+	}
+}
+
+// 第n次多项式展开后的系数
+func (this Recurrence) Unary(n int) Unary {
+	if n <= 0 {
+		return Unary{1}
+	}
+	p, q := Unary{0}, Unary{1}
+	for k := 0; k < n; k++ {
+		A, B, C := this(k)
+		r := make(Unary, k+2)
+		for i, e := range q {
+			r[i+1] += A * e
+			r[i] += B * e
+		}
+		for i, e := range p {
+			r[i] -= C * e
+		}
+		p, q = q, r
+	}
+	return q
+}
+
+// 直接用递推关系计算第n次多项式在x处的值，不展开为系数，数值稳定
+func (this Recurrence) Compute(n int, x float64) float64 {
+	y, _ := this.ComputeDerivative(n, x)
+	return y
+}
+
+// 用递推关系同时计算第n次多项式在x处的值与一阶导数
+func (this Recurrence) ComputeDerivative(n int, x float64) (float64, float64) {
+	p, q := 0.0, 1.0 // p[k-1]与p[k]
+	dp, dq := 0.0, 0.0
+	for k := 0; k < n; k++ {
+		A, B, C := this(k)
+		p, q, dp, dq = q, (A*x+B)*q-C*p, dq, A*q+(A*x+B)*dq-C*dp
+	}
+	return q, dq
+}
+
+// 第n次多项式的全部实根（升序），即以它们为节点的高斯求积公式的节点；
+// 由递推关系构造对称三对角的雅可比矩阵，用其特征值求得（Golub-Welsch方法）
+func (this Recurrence) Roots(n int) ([]float64, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	J := NewMatrix(n, n)
+	var last float64
+	for k := 0; k < n; k++ {
+		A, B, C := this(k)
+		if A == 0 {
+			return nil, errors.New("Illegal recurrence")
+		}
+		// 首一化后p[k+1]=(x-a)p[k]-b*p[k-1]，a=-B/A，b=C/(A*A')
+		J[k][k] = -B / A
+		if k > 0 {
+			b := C / (A * last)
+			if b <= 0 {
+				return nil, errors.New("Illegal recurrence")
+			}
+			J[k][k-1] = math.Sqrt(b)
+			J[k-1][k] = J[k][k-1]
+		}
+		last = A
+	}
+	e, err := J.SymEigen()
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(e.Values)
+	return e.Values, nil
+}
@@ -2,42 +2,139 @@ package algebra
 
 import (
 	"errors"
-	"fmt"
+	"math"
 )
 
+// 多项式拟合的结果及其统计诊断信息
+type FitResult struct {
+	Unary      Unary     // 拟合所得的多项式
+	Residuals  []float64 // 各点的残差y-p(x)
+	R2         float64   // 决定系数
+	AdjustedR2 float64   // 校正决定系数，自由度为零时为NaN
+	StdErr     []float64 // 各系数的标准误差，自由度为零时为NaN
+	Covariance Matrix    // 系数的协方差矩阵，自由度为零时为NaN
+}
+
 // 多项式拟合，n为拟合多项式的次数
 func UnaryFit(x, y []float64, n int) (Unary, error) {
+	r, err := UnaryFitWeighted(x, y, nil, n)
+	if err != nil {
+		return nil, err
+	}
+	return r.Unary, nil
+}
+
+// 加权多项式拟合，n为拟合多项式的次数，w为各点的权重（为nil时均取1）。
+// 使用列归一化的范德蒙矩阵的QR分解求解，避免正规方程的病态问题。
+func UnaryFitWeighted(x, y, w []float64, n int) (*FitResult, error) {
 	i, j, l := len(x), len(y), 0
 	if i < j {
 		l = i
 	} else {
 		l = j
 	}
+	if w != nil && len(w) < l {
+		l = len(w)
+	}
 	if n++; n <= 0 {
 		return nil, errors.New("Illegal input n")
 	}
 	if n > l {
 		return nil, errors.New("Data-set too small")
 	}
-	group := make([]Linear, n)
-	for i := 0; i < n; i++ {
-		group[i] = make(Linear, n+1)
+	sw := make([]float64, l)
+	for t := 0; t < l; t++ {
+		if w == nil {
+			sw[t] = 1
+		} else if w[t] < 0 || math.IsNaN(w[t]) {
+			return nil, errors.New("Illegal weight")
+		} else {
+			sw[t] = math.Sqrt(w[t])
+		}
 	}
+	A := NewMatrix(l, n)
+	b := make([]float64, l)
 	for t := 0; t < l; t++ {
-		X, Y := x[t], y[t]
-		for i, p := 0, 1.0; i < n; i, p = i+1, p*X {
-			for j, q := 0, p; j < n; j, q = j+1, q*X {
-				group[i][j] += q
-			}
-			group[i][n] -= p * Y
+		for i, p := 0, sw[t]; i < n; i, p = i+1, p*x[t] {
+			A[t][i] = p
 		}
+		b[t] = sw[t] * y[t]
 	}
-	for i:=0;i<n;i++{
-		fmt.Println(group[i])
+	d := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for t := 0; t < l; t++ {
+			d[i] = math.Hypot(d[i], A[t][i])
+		}
+		if d[i] == 0 {
+			return nil, errors.New("Data-set too small")
+		}
+		for t := 0; t < l; t++ {
+			A[t][i] /= d[i]
+		}
 	}
-	ans, err := SolveLinearGroup(group...)
+	qr, err := A.QR()
 	if err != nil {
 		return nil, err
 	}
-	return Unary(ans), nil
-}
\ No newline at end of file
+	c, err := qr.Solve(b)
+	if err != nil {
+		// 范德蒙矩阵不满秩，如不同的x值少于n个
+		return nil, errors.New("No feasible solution")
+	}
+	p := make(Unary, n)
+	for i := range p {
+		p[i] = c[i] / d[i]
+	}
+	// 加权残差平方和与加权总平方和
+	var ssr, sst, sumw, avr float64
+	res := make([]float64, l)
+	for t := 0; t < l; t++ {
+		res[t] = y[t] - p.Compute(x[t])
+		k := sw[t] * sw[t]
+		ssr += k * res[t] * res[t]
+		sumw += k
+		avr += k * y[t]
+	}
+	avr /= sumw
+	for t := 0; t < l; t++ {
+		e := y[t] - avr
+		sst += sw[t] * sw[t] * e * e
+	}
+	ans := &FitResult{Unary: p, Residuals: res, R2: 1}
+	if sst != 0 {
+		ans.R2 = 1 - ssr/sst
+	}
+	dof := l - n
+	s2 := math.NaN()
+	ans.AdjustedR2 = math.NaN()
+	if dof > 0 {
+		s2 = ssr / float64(dof)
+		ans.AdjustedR2 = 1 - (1-ans.R2)*float64(l-1)/float64(dof)
+	}
+	// 协方差矩阵为s2*(R'R)^-1，再换算回未归一化的系数
+	R := qr.R()
+	V := NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		V[j][j] = 1 / R[j][j]
+		for i := j - 1; i >= 0; i-- {
+			var t float64
+			for k := i + 1; k <= j; k++ {
+				t += R[i][k] * V[k][j]
+			}
+			V[i][j] = -t / R[i][i]
+		}
+	}
+	ans.Covariance = NewMatrix(n, n)
+	ans.StdErr = make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var t float64
+			for k := j; k < n; k++ {
+				t += V[i][k] * V[j][k]
+			}
+			ans.Covariance[i][j] = s2 * t / (d[i] * d[j])
+		}
+		ans.StdErr[i] = math.Sqrt(ans.Covariance[i][i])
+	}
+	return ans, nil
+}
@@ -0,0 +1,123 @@
+package algebra
+
+import "math"
+
+// Householder变换QR分解的结果，A=QR，要求A的行数不少于列数
+type QR struct {
+	qr    Matrix
+	rdiag []float64
+}
+
+// 对矩阵进行QR分解
+func (this Matrix) QR() (*QR, error) {
+	m, n := this.Rows(), this.Cols()
+	if n == 0 || m < n {
+		return nil, ErrDimension
+	}
+	for i := range this {
+		if len(this[i]) != n {
+			return nil, ErrDimension
+		}
+	}
+	a := this.Copy()
+	d := make([]float64, n)
+	for k := 0; k < n; k++ {
+		var s float64
+		for i := k; i < m; i++ {
+			s = math.Hypot(s, a[i][k])
+		}
+		if s != 0 {
+			if a[k][k] < 0 {
+				s = -s
+			}
+			for i := k; i < m; i++ {
+				a[i][k] /= s
+			}
+			a[k][k]++
+			for j := k + 1; j < n; j++ {
+				var t float64
+				for i := k; i < m; i++ {
+					t += a[i][k] * a[i][j]
+				}
+				t = -t / a[k][k]
+				for i := k; i < m; i++ {
+					a[i][j] += t * a[i][k]
+				}
+			}
+		}
+		d[k] = -s
+	}
+	return &QR{qr: a, rdiag: d}, nil
+}
+
+// 判断矩阵是否列满秩：R的对角元的绝对值均大于max(m,n)*epsilon*max|R[j][j]|
+func (this *QR) FullRank() bool {
+	var top float64
+	for _, e := range this.rdiag {
+		top = math.Max(top, math.Abs(e))
+	}
+	tol := float64(len(this.qr)) * epsilon * top
+	for _, e := range this.rdiag {
+		if math.Abs(e) <= tol {
+			return false
+		}
+	}
+	return true
+}
+
+// 上三角矩阵R
+func (this *QR) R() Matrix {
+	n := len(this.rdiag)
+	r := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		r[i][i] = this.rdiag[i]
+		for j := i + 1; j < n; j++ {
+			r[i][j] = this.qr[i][j]
+		}
+	}
+	return r
+}
+
+// 计算Q'b，Q'为Q的转置
+func (this *QR) QTMul(b []float64) ([]float64, error) {
+	m, n := len(this.qr), len(this.rdiag)
+	if len(b) != m {
+		return nil, ErrDimension
+	}
+	y := make([]float64, m)
+	copy(y, b)
+	for k := 0; k < n; k++ {
+		if this.rdiag[k] == 0 {
+			continue
+		}
+		var t float64
+		for i := k; i < m; i++ {
+			t += this.qr[i][k] * y[i]
+		}
+		t = -t / this.qr[k][k]
+		for i := k; i < m; i++ {
+			y[i] += t * this.qr[i][k]
+		}
+	}
+	return y, nil
+}
+
+// 求Ax=b的最小二乘解，矩阵不满秩时返回ErrSingular
+func (this *QR) Solve(b []float64) ([]float64, error) {
+	if !this.FullRank() {
+		return nil, ErrSingular
+	}
+	y, err := this.QTMul(b)
+	if err != nil {
+		return nil, err
+	}
+	n := len(this.rdiag)
+	x := y[:n]
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= this.qr[i][j] * x[j]
+		}
+		x[i] /= this.rdiag[i]
+	}
+	return x, nil
+}
@@ -0,0 +1,179 @@
+package algebra
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// 约分时判断余式为零的误差限（相对于归一化后的系数）
+const rationalTol = 1e-9
+
+var ErrZeroDenominator = errors.New("Zero denominator")
+
+// 有理函数P(x)/Q(x)，总是约去公因式，且Q为首一多项式
+type Rational struct {
+	P, Q Unary
+}
+
+// 创建有理函数p/q并约分，q为零多项式时返回ErrZeroDenominator
+func NewRational(p, q Unary) (*Rational, error) {
+	if maxAbs(q) == 0 {
+		return nil, ErrZeroDenominator
+	}
+	this := &Rational{p.Trim(0), q.Trim(0)}
+	this.reduce()
+	return this, nil
+}
+
+// 约去公因式并将分母首一化
+func (this *Rational) reduce() {
+	if maxAbs(this.P) == 0 {
+		this.P, this.Q = Unary{0}, Unary{1}
+		return
+	}
+	if g := this.P.GCD(this.Q, rationalTol); len(g) > 1 {
+		this.P = this.P.Div(g).Trim(rationalTol)
+		this.Q = this.Q.Div(g).Trim(rationalTol)
+	}
+	k := 1 / this.Q[len(this.Q)-1]
+	this.P, this.Q = this.P.ScalarMul(k), this.Q.ScalarMul(k)
+}
+
+// 计算有理函数的值，x为极点时结果为无穷或NaN
+func (this *Rational) Compute(x float64) float64 {
+	return this.P.Compute(x) / this.Q.Compute(x)
+}
+
+// 计算有理函数在复数点的值
+func (this *Rational) ComputeComplex(x complex128) complex128 {
+	return this.P.Complex().Compute(x) / this.Q.Complex().Compute(x)
+}
+
+// 有理函数相加
+func (this *Rational) Add(that *Rational) *Rational {
+	r := &Rational{this.P.Mul(that.Q).Add(that.P.Mul(this.Q)), this.Q.Mul(that.Q)}
+	r.reduce()
+	return r
+}
+
+// 有理函数相减
+func (this *Rational) Sub(that *Rational) *Rational {
+	r := &Rational{this.P.Mul(that.Q).Sub(that.P.Mul(this.Q)), this.Q.Mul(that.Q)}
+	r.reduce()
+	return r
+}
+
+// 有理函数相乘
+func (this *Rational) Mul(that *Rational) *Rational {
+	r := &Rational{this.P.Mul(that.P), this.Q.Mul(that.Q)}
+	r.reduce()
+	return r
+}
+
+// 有理函数相除，除数为零时返回ErrZeroDenominator
+func (this *Rational) Div(that *Rational) (*Rational, error) {
+	if maxAbs(that.P) == 0 {
+		return nil, ErrZeroDenominator
+	}
+	r := &Rational{this.P.Mul(that.Q), this.Q.Mul(that.P)}
+	r.reduce()
+	return r, nil
+}
+
+// 求导函数，(P'Q-PQ')/Q^2
+func (this *Rational) Reduce() *Rational {
+	r := &Rational{this.P.Reduce().Mul(this.Q).Sub(this.P.Mul(this.Q.Reduce())), this.Q.Mul(this.Q)}
+	r.reduce()
+	return r
+}
+
+func (this *Rational) String() string {
+	return fmt.Sprintf("(%v)/(%v)", this.P, this.Q)
+}
+
+// 零点（分子的根），重根按重数重复给出，按实部、虚部升序排列
+func (this *Rational) Zeros() []complex128 {
+	return SolveUnaryComplex(this.P)
+}
+
+// 极点（分母的根），重根按重数重复给出，按实部、虚部升序排列
+func (this *Rational) Poles() []complex128 {
+	return SolveUnaryComplex(this.Q)
+}
+
+// 部分分式的一项Coef/(x-Pole)^Order
+type PartialFraction struct {
+	Pole  complex128
+	Order int
+	Coef  complex128
+}
+
+// 多项式在z处的泰勒展开的前m个系数，即按(x-z)的升幂排列的系数
+func taylor(p ComplexUnary, z complex128, m int) []complex128 {
+	c := make(ComplexUnary, len(p))
+	copy(c, p)
+	ans := make([]complex128, m)
+	for k := 0; k < m && k < len(c); k++ {
+		// 综合除法：c=(x-z)*q+r，r即第k个系数
+		for i := len(c) - 2; i >= k; i-- {
+			c[i] += z * c[i+1]
+		}
+		ans[k] = c[k]
+	}
+	return ans
+}
+
+// 部分分式分解：P/Q=S+ΣCoef/(x-Pole)^Order，返回多项式部分S与各项，
+// 同一极点的各项按Order降序排列，极点的顺序与Poles相同
+func (this *Rational) PartialFractions() (Unary, []PartialFraction) {
+	s, r := this.P.DivMod(this.Q)
+	if len(this.Q) <= 1 {
+		return s, nil
+	}
+	type pole struct {
+		z complex128
+		m int
+	}
+	var ps []pole
+	// 合并后的重根完全相等，相同的值即为同一极点
+	for _, z := range SolveUnaryComplex(this.Q) {
+		if l := len(ps) - 1; l >= 0 && ps[l].z == z {
+			ps[l].m++
+		} else {
+			ps = append(ps, pole{z, 1})
+		}
+	}
+	R := r.Complex()
+	var ans []PartialFraction
+	for i, p := range ps {
+		// 其余极点对应的因式之积（Q首一）
+		q := ComplexUnary{1}
+		for j, o := range ps {
+			if j != i {
+				for k := 0; k < o.m; k++ {
+					q = q.Mul(ComplexUnary{-o.z, 1})
+				}
+			}
+		}
+		a, b := taylor(R, p.z, p.m), taylor(q, p.z, p.m)
+		// 幂级数相除h=a/b，h的第k项对应Order为m-k的分式
+		h := make([]complex128, p.m)
+		for k := range h {
+			h[k] = a[k]
+			for j := 1; j <= k; j++ {
+				h[k] -= b[j] * h[k-j]
+			}
+			h[k] /= b[0]
+			ans = append(ans, PartialFraction{p.z, p.m - k, h[k]})
+		}
+	}
+	sort.SliceStable(ans, func(i, j int) bool {
+		a, b := ans[i].Pole, ans[j].Pole
+		if real(a) != real(b) {
+			return real(a) < real(b)
+		}
+		return imag(a) < imag(b)
+	})
+	return s, ans
+}
@@ -0,0 +1,341 @@
+package algebra
+
+import (
+	"errors"
+	"math/big"
+)
+
+// 有理系数的一元多项式，用math/big.Rat精确运算，a[0]+a[1]*x+...+a[n]*x^n；
+// 运算结果均去掉了为零的高次项，零多项式表示为{0}
+type RatUnary []*big.Rat
+
+// 多元一次多项式的有理系数版本，a[0]*x+a[1]*y+...+a[n]
+type RatLinear []*big.Rat
+
+// 以整数系数构造多项式
+func NewRatUnary(a ...int64) RatUnary {
+	r := make(RatUnary, len(a))
+	for i, e := range a {
+		r[i] = big.NewRat(e, 1)
+	}
+	return r.trim()
+}
+
+// 转换为有理系数多项式，每个系数都精确等于原浮点数；系数含无穷或NaN时返回nil
+func (this Unary) Rat() RatUnary {
+	r := make(RatUnary, len(this))
+	for i, e := range this {
+		if r[i] = new(big.Rat).SetFloat64(e); r[i] == nil {
+			return nil
+		}
+	}
+	return r.trim()
+}
+
+// 转换为浮点系数多项式，每个系数取最接近的浮点数
+func (this RatUnary) Unary() Unary {
+	r := make(Unary, len(this))
+	for i, e := range this {
+		r[i], _ = e.Float64()
+	}
+	return r
+}
+
+// 转换为有理系数；系数含无穷或NaN时返回nil
+func (this Linear) Rat() RatLinear {
+	r := make(RatLinear, len(this))
+	for i, e := range this {
+		if r[i] = new(big.Rat).SetFloat64(e); r[i] == nil {
+			return nil
+		}
+	}
+	return r
+}
+
+// 去掉为零的高次项，返回新的切片，系数均为副本
+func (this RatUnary) trim() RatUnary {
+	n := len(this)
+	for n > 1 && this[n-1].Sign() == 0 {
+		n--
+	}
+	if n == 0 {
+		return RatUnary{new(big.Rat)}
+	}
+	r := make(RatUnary, n)
+	for i := range r {
+		r[i] = new(big.Rat).Set(this[i])
+	}
+	return r
+}
+
+// 返回多项式的次数，零多项式为0
+func (this RatUnary) Order() int {
+	return len(this.trim()) - 1
+}
+
+// 是否为零多项式
+func (this RatUnary) IsZero() bool {
+	for _, e := range this {
+		if e.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// 两个多项式是否完全相等
+func (this RatUnary) Equal(that RatUnary) bool {
+	p, q := this.trim(), that.trim()
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i].Cmp(q[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// 计算多项式函数的精确值
+func (this RatUnary) Compute(x *big.Rat) *big.Rat {
+	y := new(big.Rat)
+	for i := len(this) - 1; i >= 0; i-- {
+		y.Mul(y, x)
+		y.Add(y, this[i])
+	}
+	return y
+}
+
+// 求微分多项式
+func (this RatUnary) Reduce() RatUnary {
+	if len(this) <= 1 {
+		return RatUnary{new(big.Rat)}
+	}
+	r := make(RatUnary, len(this)-1)
+	for i, e := range this[1:] {
+		r[i] = new(big.Rat).Mul(e, big.NewRat(int64(i+1), 1))
+	}
+	return r.trim()
+}
+
+// 求积分多项式
+func (this RatUnary) Integral() RatUnary {
+	r := make(RatUnary, len(this)+1)
+	r[0] = new(big.Rat)
+	for i, e := range this {
+		r[i+1] = new(big.Rat).Quo(e, big.NewRat(int64(i+1), 1))
+	}
+	return r.trim()
+}
+
+// 多项式相加
+func (this RatUnary) Add(that RatUnary) RatUnary {
+	if len(this) < len(that) {
+		this, that = that, this
+	}
+	r := make(RatUnary, len(this))
+	for i := range r {
+		r[i] = new(big.Rat).Set(this[i])
+		if i < len(that) {
+			r[i].Add(r[i], that[i])
+		}
+	}
+	return r.trim()
+}
+
+// 多项式相减
+func (this RatUnary) Sub(that RatUnary) RatUnary {
+	return this.Add(that.ScalarMul(big.NewRat(-1, 1)))
+}
+
+// 多项式相乘
+func (this RatUnary) Mul(that RatUnary) RatUnary {
+	if len(this) == 0 || len(that) == 0 {
+		return RatUnary{new(big.Rat)}
+	}
+	r := make(RatUnary, len(this)+len(that)-1)
+	for i := range r {
+		r[i] = new(big.Rat)
+	}
+	t := new(big.Rat)
+	for i, a := range this {
+		if a.Sign() == 0 {
+			continue
+		}
+		for j, b := range that {
+			r[i+j].Add(r[i+j], t.Mul(a, b))
+		}
+	}
+	return r.trim()
+}
+
+// 乘以一个系数
+func (this RatUnary) ScalarMul(k *big.Rat) RatUnary {
+	r := make(RatUnary, len(this))
+	for i, e := range this {
+		r[i] = new(big.Rat).Mul(e, k)
+	}
+	return r.trim()
+}
+
+// n个p相乘
+func (this RatUnary) Pow(n uint) RatUnary {
+	r, p := NewRatUnary(1), this.trim()
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			r = r.Mul(p)
+		}
+		if n > 1 {
+			p = p.Mul(p)
+		}
+	}
+	return r
+}
+
+// 多项式相除并取余，余式次数低于除式；除式为零多项式时panic
+func (this RatUnary) DivMod(that RatUnary) (RatUnary, RatUnary) {
+	u, v := this.trim(), that.trim()
+	x, y := len(u), len(v)
+	if v.IsZero() {
+		panic("division by zero polynomial")
+	}
+	if y > x {
+		return RatUnary{new(big.Rat)}, u
+	}
+	q := make(RatUnary, x-y+1)
+	t := new(big.Rat)
+	for i, j := x-y, x-1; i >= 0; i, j = i-1, j-1 {
+		k := new(big.Rat).Quo(u[j], v[y-1])
+		for a, b := j, y-1; b >= 0; a, b = a-1, b-1 {
+			u[a].Sub(u[a], t.Mul(k, v[b]))
+		}
+		q[i] = k
+	}
+	return q.trim(), u[:y-1].trim()
+}
+
+// 多项式相除
+func (this RatUnary) Div(that RatUnary) RatUnary {
+	q, _ := this.DivMod(that)
+	return q
+}
+
+// 多项式取余
+func (this RatUnary) Mod(that RatUnary) RatUnary {
+	_, r := this.DivMod(that)
+	return r
+}
+
+// 首一化，零多项式原样返回
+func (this RatUnary) Monic() RatUnary {
+	p := this.trim()
+	if p.IsZero() {
+		return p
+	}
+	return p.ScalarMul(new(big.Rat).Inv(p[len(p)-1]))
+}
+
+// 精确求首一化的最大公因式；两者均为零时返回{0}
+func (this RatUnary) GCD(that RatUnary) RatUnary {
+	p, q := this.trim(), that.trim()
+	for !q.IsZero() {
+		p, q = q, p.Mod(q).Monic()
+	}
+	return p.Monic()
+}
+
+// 精确的无平方因式分解（Yun算法）：返回f[0],f[1],...，使this=c*f[0]*f[1]^2*f[2]^3*...，各f[i]首一且两两互素
+func (this RatUnary) SquareFree() []RatUnary {
+	p := this.Monic()
+	if len(p) <= 1 {
+		return nil
+	}
+	q := p.Reduce()
+	a := p.GCD(q)
+	b, c := p.Div(a), q.Div(a)
+	var ans []RatUnary
+	for len(b) > 1 {
+		d := c.Sub(b.Reduce())
+		a = b.GCD(d)
+		ans = append(ans, a)
+		b, c = b.Div(a), d.Div(a)
+	}
+	for len(ans) > 0 && len(ans[len(ans)-1]) == 1 {
+		ans = ans[:len(ans)-1]
+	}
+	return ans
+}
+
+// 提供变量的值以精确计算多项式的值
+func (this RatLinear) Compute(x ...*big.Rat) (*big.Rat, error) {
+	n := len(x)
+	if n != len(this)-1 {
+		return nil, errors.New("Mismatched number of variables")
+	}
+	ans, t := new(big.Rat).Set(this[n]), new(big.Rat)
+	for i, e := range x {
+		ans.Add(ans, t.Mul(this[i], e))
+	}
+	return ans, nil
+}
+
+// 精确求解有理系数的多元一次方程组
+func SolveLinearGroupRat(p ...RatLinear) ([]*big.Rat, error) {
+	if len(p) == 0 {
+		return nil, errors.New("Find no ploynomials")
+	}
+	n, l := len(p), len(p[0])
+	if n+1 != l {
+		return nil, errors.New("Mismatched number of ploynomials")
+	}
+	M := make([]RatLinear, n)
+	for i := range p {
+		if len(p[i]) != l {
+			return nil, errors.New("Mismatched number of variables")
+		}
+		M[i] = make(RatLinear, l)
+		for j, e := range p[i] {
+			M[i][j] = new(big.Rat).Set(e)
+		}
+	}
+	t := new(big.Rat)
+	for i := 0; i < n; i++ {
+		j := i
+		for j < n && M[j][i].Sign() == 0 {
+			j++
+		}
+		if j == n {
+			return nil, errors.New("No feasible solution")
+		}
+		M[i], M[j] = M[j], M[i]
+		K := new(big.Rat).Inv(M[i][i])
+		for k := i; k < l; k++ {
+			M[i][k].Mul(M[i][k], K)
+		}
+		for k := 0; k < n; k++ {
+			if k != i && M[k][i].Sign() != 0 {
+				K.Set(M[k][i])
+				for s := i; s < l; s++ {
+					M[k][s].Sub(M[k][s], t.Mul(M[i][s], K))
+				}
+			}
+		}
+	}
+	ans := make([]*big.Rat, n)
+	for i := range ans {
+		ans[i] = new(big.Rat).Neg(M[i][n])
+	}
+	return ans, nil
+}
+
+// 精确求解多元一次方程组，系数先精确转换为有理数；系数含无穷或NaN时返回错误
+func SolveLinearGroupExact(p ...Linear) ([]*big.Rat, error) {
+	q := make([]RatLinear, len(p))
+	for i, e := range p {
+		if q[i] = e.Rat(); q[i] == nil {
+			return nil, errors.New("Coefficient not finite")
+		}
+	}
+	return SolveLinearGroupRat(q...)
+}
@@ -3,9 +3,10 @@ package algebra
 import (
 	"errors"
 	"math"
+	"sort"
 )
 
-// 二分法+折线法联合求解一元方程，如果区间内有多个解，只能求出其中一个。
+// 二分法+折线法联合求解一元方程，如果区间内有多个解，只能求出其中一个；f(a)与f(b)同号时返回ErrNotBracketed。
 func Region(f func(float64) float64, a, b float64) (float64, error) {
 	var l, r, p, q, x, y float64
 	if a > b {
@@ -44,39 +45,72 @@ func Region(f func(float64) float64, a, b float64) (float64, error) {
 			}
 		}
 	}
-	return 0, errors.New("Value f(a) & f(b) have the same sign")
+	return 0, ErrNotBracketed
+}
+
+// 切线法的最大迭代次数，超过时认为迭代陷入循环
+const tangentMaxIter = 1000
+
+// 牛顿步长d已小于x处的舍入误差，或者已很小却不比上一步长last更小（f的值被舍入误差淹没），
+// 再迭代只会在附近来回，永远等不到f(x)==0
+func stalled(d, last, x float64) bool {
+	e := math.Abs(d)
+	return e <= 2*epsilon*math.Abs(x) || e >= last && e <= 1e-8*(1+math.Abs(x))
 }
 
 // 切线法求一元方程解，f为求解函数，k为f的导函数，求解区间为[p,q]，x为迭代起始点。
+// 迭代tangentMaxIter次仍未收敛时返回ConvergenceError。
 func Tangent(f, k func(float64) float64, p, q float64, x float64) (float64, error) {
 	var y float64
 	if p > q {
 		p, q = q, p
 	}
-	for {
+	last := math.Inf(1)
+	for n := 0; ; n++ {
+		if n == tangentMaxIter {
+			return 0, &ConvergenceError{"Tangent", n}
+		}
 		if y = f(x); y == 0 {
 			return x, nil
 		}
-		if x -= y / k(x); x < p || x > q {
+		d := y / k(x)
+		if stalled(d, last, x) {
+			return x - d, nil
+		}
+		last = math.Abs(d)
+		if x -= d; x < p || x > q {
 			break
 		}
 	}
 	return 0, errors.New("Focal point of tangent line and x-axis outside the region")
 }
 
-// 用切线法生成求解区间，再用Region函数求解，要求函数单调递增/递减，且无导数为0的点
+// 用切线法生成求解区间，再用Region函数求解，要求函数单调递增/递减，且无导数为0的点；
+// 迭代tangentMaxIter次仍未找到求解区间时返回ConvergenceError
 func Monotone(f, k func(float64) float64) (float64, error) {
 	var x, y float64 = 0, f(0)
 	if y == 0 {
 		return 0, nil
 	}
 	sp := y > 0
-	for {
-		x -= y / k(x)
-		if math.IsInf(x, -1) || math.IsInf(x, +1) {
+	last := math.Inf(1)
+	for n := 0; ; n++ {
+		d := y / k(x)
+		if stalled(d, last, x) {
+			// 牛顿迭代从一侧收敛到根，不会再变号
+			return x - d, nil
+		}
+		if n == tangentMaxIter {
+			return 0, &ConvergenceError{"Monotone", n}
+		}
+		last = math.Abs(d)
+		if x -= d; math.IsInf(x, -1) || math.IsInf(x, +1) {
 			return 0, errors.New("Failed to make search region")
 		}
-		if y = f(x); (y > 0) != sp {
+		if y = f(x); y == 0 {
+			return x, nil
+		}
+		if (y > 0) != sp {
 			break
 		}
 	}
@@ -84,3 +118,140 @@ func Monotone(f, k func(float64) float64) (float64, error) {
 	return x, e
 }
 
+// FindAllRoots的参数，零值字段使用默认值
+type ScanOptions struct {
+	Options
+	Samples int     // 初始均匀采样的区间数，默认100
+	Depth   int     // 自适应细分的最大层数，默认10
+	Tangent bool    // 是否检测函数值不变号的切点（偶数重根）
+	ZeroTol float64 // 切点处|f(x)|的上限，默认1e-10
+}
+
+// 求区间[a,b]内的全部解：先均匀采样，在函数明显弯曲处自适应细分；
+// 用Region求解每个变号区间，并在|f|的局部极小值附近搜索未变号的成对解及切点。
+// 结果升序排列且已去重。
+func FindAllRoots(f func(float64) float64, a, b float64, opt *ScanOptions) ([]float64, error) {
+	var s ScanOptions
+	if opt != nil {
+		s = *opt
+	}
+	o := s.Options.normalize()
+	if s.Samples <= 0 {
+		s.Samples = 100
+	}
+	if s.Depth <= 0 {
+		s.Depth = 10
+	}
+	if s.ZeroTol <= 0 {
+		s.ZeroTol = 1e-10
+	}
+	if a > b {
+		a, b = b, a
+	}
+	// 采样
+	xs, ys := []float64{a}, []float64{f(a)}
+	var scan func(l, r, fl, fr float64, depth int) error
+	scan = func(l, r, fl, fr float64, depth int) error {
+		if err := o.cancelled(); err != nil {
+			return err
+		}
+		if depth > 0 {
+			m := (l + r) / 2
+			fm := f(m)
+			if math.Abs(fm-(fl+fr)/2) > (math.Abs(fl)+math.Abs(fr))/8 {
+				if err := scan(l, m, fl, fm, depth-1); err != nil {
+					return err
+				}
+				return scan(m, r, fm, fr, depth-1)
+			}
+		}
+		xs, ys = append(xs, r), append(ys, fr)
+		return nil
+	}
+	for i := 1; i <= s.Samples; i++ {
+		l, fl := xs[len(xs)-1], ys[len(ys)-1]
+		r := a + (b-a)*float64(i)/float64(s.Samples)
+		if err := scan(l, r, fl, f(r), s.Depth); err != nil {
+			return nil, err
+		}
+	}
+	// 变号区间
+	var ans []float64
+	for i, y := range ys {
+		if y == 0 {
+			ans = append(ans, xs[i])
+		} else if i > 0 && ys[i-1] != 0 && (y > 0) != (ys[i-1] > 0) {
+			if x, e := Region(f, xs[i-1], xs[i]); e == nil {
+				ans = append(ans, x)
+			}
+		}
+	}
+	// |f|的局部极小值两侧
+	for i := 1; i < len(ys)-1; i++ {
+		p, y, q := ys[i-1], ys[i], ys[i+1]
+		if p == 0 || q == 0 || (p > 0) != (q > 0) || (y != 0 && (y > 0) != (p > 0)) ||
+			math.Abs(y) > math.Abs(p) || math.Abs(y) > math.Abs(q) {
+			continue
+		}
+		sign := 1.0
+		if p < 0 {
+			sign = -1
+		}
+		for _, c := range [][2]float64{{xs[i-1], xs[i]}, {xs[i], xs[i+1]}} {
+			if err := o.cancelled(); err != nil {
+				return nil, err
+			}
+			x, v := valley(f, c[0], c[1], sign)
+			switch {
+			case v <= 0:
+				for _, d := range [][2]float64{{c[0], x}, {x, c[1]}} {
+					if x, e := Region(f, d[0], d[1]); e == nil {
+						ans = append(ans, x)
+					}
+				}
+			case s.Tangent && v <= s.ZeroTol:
+				ans = append(ans, x)
+			}
+		}
+	}
+	if len(ans) == 0 {
+		return nil, nil
+	}
+	// 相距不超过采样分辨率的解视为同一个
+	w := (b - a) / float64(s.Samples) / math.Ldexp(1, s.Depth)
+	sort.Float64s(ans)
+	j := 1
+	for i := 1; i < len(ans); i++ {
+		if ans[i]-ans[j-1] > math.Max(o.tol(ans[i]), w) {
+			ans[j], j = ans[i], j+1
+		}
+	}
+	return ans[:j], nil
+}
+
+// 黄金分割法求sign*f在[a,b]上的极小值点及sign*f的值，一旦sign*f不大于零即返回
+func valley(f func(float64) float64, a, b, sign float64) (float64, float64) {
+	const g = 0.6180339887498949
+	c, d := b-g*(b-a), a+g*(b-a)
+	fc, fd := sign*f(c), sign*f(d)
+	for i := 0; i < 100 && c < d; i++ {
+		switch {
+		case fc <= 0:
+			return c, fc
+		case fd <= 0:
+			return d, fd
+		case fc < fd:
+			b, d, fd = d, c, fc
+			c = b - g*(b-a)
+			fc = sign * f(c)
+		default:
+			a, c, fc = c, d, fd
+			d = a + g*(b-a)
+			fd = sign * f(d)
+		}
+	}
+	if fc < fd {
+		return c, fc
+	}
+	return d, fd
+}
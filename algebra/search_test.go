@@ -0,0 +1,40 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTangentCycle(t *testing.T) {
+	// 从0出发的牛顿迭代在0与1之间循环
+	f := func(x float64) float64 { return x*x*x - 2*x + 2 }
+	k := func(x float64) float64 { return 3*x*x - 2 }
+	_, err := Tangent(f, k, -10, 10, 0)
+	var ce *ConvergenceError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Tangent returned %v, want ConvergenceError", err)
+	}
+	if _, err := TangentDiff(f, -10, 10, 0); !errors.As(err, &ce) {
+		t.Fatalf("TangentDiff returned %v, want ConvergenceError", err)
+	}
+}
+
+func TestTangentRounding(t *testing.T) {
+	// 根附近f的值被舍入误差淹没，牛顿步长停在1e-13左右而不会减小到机器精度
+	p := Unary{720, -1764, 1624, -735, 175, -21, 1}.Reduce()
+	q := p.Reduce()
+	x, err := Tangent(p.Compute, q.Compute, 5.3, 1e9, 6.3)
+	if err != nil || math.Abs(x-5.663446526735252) > 1e-9 {
+		t.Fatalf("Tangent = %v, %v", x, err)
+	}
+}
+
+func TestMonotoneExactRoot(t *testing.T) {
+	// 第一步牛顿迭代恰好落在根上
+	p := Unary{-1, 1, -2, 2, -1, 1}
+	x, err := Monotone(p.Compute, p.Reduce().Compute)
+	if err != nil || x != 1 {
+		t.Fatalf("Monotone = %v, %v", x, err)
+	}
+}
@@ -0,0 +1,398 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+)
+
+// 约束条件中多项式与零的关系
+type Relation int
+
+const (
+	LessEqual    Relation = iota // p(x) <= 0
+	GreaterEqual                 // p(x) >= 0
+	Equal                        // p(x) == 0
+)
+
+// 线性约束：Linear(x) Relation 0
+type Constraint struct {
+	Linear   Linear
+	Relation Relation
+}
+
+// 线性规划的求解状态
+type LPStatus int
+
+const (
+	LPOptimal    LPStatus = iota // 求得最优解
+	LPInfeasible                 // 无可行解
+	LPUnbounded                  // 目标函数无界
+)
+
+func (this LPStatus) String() string {
+	switch this {
+	case LPOptimal:
+		return "Optimal"
+	case LPInfeasible:
+		return "Infeasible"
+	case LPUnbounded:
+		return "Unbounded"
+	}
+	return "Unknown"
+}
+
+// 线性规划问题：在约束条件与变量界下求目标函数的最小值（Maximize为真时求最大值）
+type LP struct {
+	Objective   Linear
+	Constraints []Constraint
+	Lower       []float64 // 各变量的下界，nil表示全为0；元素可为负无穷
+	Upper       []float64 // 各变量的上界，nil表示全为正无穷
+	Maximize    bool
+}
+
+// 线性规划的解
+type LPResult struct {
+	Status LPStatus
+	X      []float64 // 最优解
+	Value  float64   // 目标函数的最优值
+	Duals  []float64 // 各约束的对偶值（影子价格），即常数项减少一个单位时最优值的变化量
+}
+
+const lpTol = 1e-9
+
+// 有界变量单纯形法的工作状态
+type simplex struct {
+	a      Matrix    // 约束矩阵，每行一个约束，列为全部变量（含松弛变量与人工变量）
+	b      []float64 // 右端常数
+	c      []float64 // 当前阶段的目标系数
+	l, u   []float64 // 变量界
+	x      []float64 // 变量值
+	basis  []int     // 各行的基变量
+	isBase []bool
+	inv    Matrix // 基矩阵的逆
+}
+
+// 重新计算基矩阵的逆与基变量的值
+func (this *simplex) refactor() error {
+	m := len(this.b)
+	B := NewMatrix(m, m)
+	for i := 0; i < m; i++ {
+		for k, j := range this.basis {
+			B[i][k] = this.a[i][j]
+		}
+	}
+	inv, err := B.Inverse()
+	if err != nil {
+		return err
+	}
+	this.inv = inv
+	r := make([]float64, m)
+	copy(r, this.b)
+	for j := range this.x {
+		if !this.isBase[j] && this.x[j] != 0 {
+			for i := 0; i < m; i++ {
+				r[i] -= this.a[i][j] * this.x[j]
+			}
+		}
+	}
+	for k, j := range this.basis {
+		var s float64
+		for i := 0; i < m; i++ {
+			s += inv[k][i] * r[i]
+		}
+		this.x[j] = s
+	}
+	return nil
+}
+
+// 对偶值y=c_B*B^-1
+func (this *simplex) duals() []float64 {
+	m := len(this.b)
+	y := make([]float64, m)
+	for k, j := range this.basis {
+		if c := this.c[j]; c != 0 {
+			for i := 0; i < m; i++ {
+				y[i] += c * this.inv[k][i]
+			}
+		}
+	}
+	return y
+}
+
+// 迭代至当前目标最优，返回是否无界
+func (this *simplex) run(limit int) (bool, error) {
+	m, n := len(this.b), len(this.x)
+	degenerate := 0
+	for iter := 0; iter < limit; iter++ {
+		if iter%50 == 49 {
+			if err := this.refactor(); err != nil {
+				return false, err
+			}
+		}
+		y := this.duals()
+		// 选择入基变量：检验数最大者，连续退化时改用Bland规则防止循环
+		enter, dir, best := -1, 0.0, lpTol
+		for j := 0; j < n; j++ {
+			if this.isBase[j] || this.l[j] == this.u[j] {
+				continue
+			}
+			d := this.c[j]
+			for i := 0; i < m; i++ {
+				d -= y[i] * this.a[i][j]
+			}
+			var s float64
+			switch {
+			case d < -lpTol && this.x[j] < this.u[j]:
+				s = +1
+			case d > lpTol && this.x[j] > this.l[j]:
+				s = -1
+			default:
+				continue
+			}
+			if math.Abs(d) > best {
+				enter, dir, best = j, s, math.Abs(d)
+				if degenerate > 50 {
+					break
+				}
+			}
+		}
+		if enter < 0 {
+			return false, nil
+		}
+		// 入基变量对应的列 alpha=B^-1*A_j
+		alpha := make([]float64, m)
+		for k := 0; k < m; k++ {
+			for i := 0; i < m; i++ {
+				alpha[k] += this.inv[k][i] * this.a[i][enter]
+			}
+		}
+		// 比值检验
+		theta, leave := this.u[enter]-this.l[enter], -1
+		for k, j := range this.basis {
+			t := dir * alpha[k]
+			var r float64
+			switch {
+			case t > lpTol && !math.IsInf(this.l[j], -1):
+				r = (this.x[j] - this.l[j]) / t
+			case t < -lpTol && !math.IsInf(this.u[j], +1):
+				r = (this.u[j] - this.x[j]) / -t
+			default:
+				continue
+			}
+			if r < 0 {
+				r = 0
+			}
+			if r < theta || leave >= 0 && r == theta && j < this.basis[leave] {
+				theta, leave = r, k
+			}
+		}
+		if math.IsInf(theta, +1) {
+			return true, nil
+		}
+		if theta <= lpTol {
+			degenerate++
+		} else {
+			degenerate = 0
+		}
+		this.x[enter] += dir * theta
+		for k, j := range this.basis {
+			this.x[j] -= dir * theta * alpha[k]
+		}
+		if leave < 0 {
+			// 入基变量直接从一个界移到另一个界
+			continue
+		}
+		// 出基变量落在它触及的界上
+		out := this.basis[leave]
+		if dir*alpha[leave] > 0 {
+			this.x[out] = this.l[out]
+		} else {
+			this.x[out] = this.u[out]
+		}
+		this.isBase[out], this.isBase[enter] = false, true
+		this.basis[leave] = enter
+		// 更新基矩阵的逆
+		p := alpha[leave]
+		for i := 0; i < m; i++ {
+			this.inv[leave][i] /= p
+		}
+		for k := 0; k < m; k++ {
+			if k != leave && alpha[k] != 0 {
+				f := alpha[k]
+				for i := 0; i < m; i++ {
+					this.inv[k][i] -= f * this.inv[leave][i]
+				}
+			}
+		}
+	}
+	return false, &ConvergenceError{"Simplex", limit}
+}
+
+// 用两阶段有界修正单纯形法求解线性规划
+func (this *LP) Solve() (*LPResult, error) {
+	n := len(this.Objective) - 1
+	m := len(this.Constraints)
+	if n <= 0 {
+		return nil, errors.New("Find no variables")
+	}
+	if this.Lower != nil && len(this.Lower) != n || this.Upper != nil && len(this.Upper) != n {
+		return nil, errors.New("Mismatched number of variables")
+	}
+	for _, c := range this.Constraints {
+		if len(c.Linear) != n+1 {
+			return nil, errors.New("Mismatched number of variables")
+		}
+	}
+	if m == 0 {
+		return this.solveBounds(n)
+	}
+	// 变量依次为：原变量n个、松弛变量m个、人工变量m个
+	N := n + 2*m
+	s := &simplex{
+		a: NewMatrix(m, N), b: make([]float64, m), c: make([]float64, N),
+		l: make([]float64, N), u: make([]float64, N), x: make([]float64, N),
+		basis: make([]int, m), isBase: make([]bool, N),
+	}
+	for j := 0; j < n; j++ {
+		s.l[j], s.u[j] = 0, math.Inf(+1)
+		if this.Lower != nil {
+			s.l[j] = this.Lower[j]
+		}
+		if this.Upper != nil {
+			s.u[j] = this.Upper[j]
+		}
+		if s.l[j] > s.u[j] {
+			return &LPResult{Status: LPInfeasible}, nil
+		}
+		// 非基变量取有限的界，自由变量取0
+		switch {
+		case !math.IsInf(s.l[j], -1):
+			s.x[j] = s.l[j]
+		case !math.IsInf(s.u[j], +1):
+			s.x[j] = s.u[j]
+		}
+	}
+	for i, c := range this.Constraints {
+		copy(s.a[i], c.Linear[:n])
+		s.b[i] = -c.Linear[n]
+		j := n + i
+		s.a[i][j] = 1
+		switch c.Relation {
+		case LessEqual:
+			s.l[j], s.u[j] = 0, math.Inf(+1)
+		case GreaterEqual:
+			s.l[j], s.u[j] = math.Inf(-1), 0
+		default:
+			s.l[j], s.u[j] = 0, 0
+		}
+		// 松弛变量取值可行时作为初始基变量，否则引入人工变量
+		r := s.b[i]
+		for k := 0; k < n; k++ {
+			r -= s.a[i][k] * s.x[k]
+		}
+		if r >= s.l[j] && r <= s.u[j] {
+			s.basis[i] = j
+		} else {
+			if r > s.u[j] {
+				s.x[j] = s.u[j]
+			} else {
+				s.x[j] = s.l[j]
+			}
+			t := n + m + i
+			if r < s.x[j] {
+				s.a[i][t] = -1
+			} else {
+				s.a[i][t] = 1
+			}
+			s.u[t], s.c[t] = math.Inf(+1), 1
+			s.basis[i] = t
+		}
+		s.isBase[s.basis[i]] = true
+	}
+	for j := n + m; j < N; j++ {
+		if !s.isBase[j] {
+			s.u[j] = 0
+		}
+	}
+	limit := 50 * (N + m + 10)
+	if err := s.refactor(); err != nil {
+		return nil, err
+	}
+	// 第一阶段：使人工变量之和最小
+	if _, err := s.run(limit); err != nil {
+		return nil, err
+	}
+	var infeas float64
+	for j := n + m; j < N; j++ {
+		infeas += s.x[j]
+	}
+	if infeas > lpTol*math.Max(1, normInf(s.b)) {
+		return &LPResult{Status: LPInfeasible}, nil
+	}
+	// 第二阶段：人工变量固定为0，求原目标函数的最小值
+	sign := 1.0
+	if this.Maximize {
+		sign = -1
+	}
+	for j := range s.c {
+		s.c[j] = 0
+	}
+	for j := 0; j < n; j++ {
+		s.c[j] = sign * this.Objective[j]
+	}
+	for j := n + m; j < N; j++ {
+		s.x[j], s.u[j] = 0, 0
+	}
+	if err := s.refactor(); err != nil {
+		return nil, err
+	}
+	unbounded, err := s.run(limit)
+	if err != nil {
+		return nil, err
+	}
+	if unbounded {
+		return &LPResult{Status: LPUnbounded}, nil
+	}
+	res := &LPResult{Status: LPOptimal, X: make([]float64, n), Duals: s.duals()}
+	copy(res.X, s.x[:n])
+	res.Value, _ = this.Objective.Compute(res.X...)
+	for i := range res.Duals {
+		res.Duals[i] *= sign
+	}
+	return res, nil
+}
+
+// 没有约束条件时，每个变量各自取使其目标项最小的界
+func (this *LP) solveBounds(n int) (*LPResult, error) {
+	sign := 1.0
+	if this.Maximize {
+		sign = -1
+	}
+	res := &LPResult{Status: LPOptimal, X: make([]float64, n), Duals: []float64{}}
+	for j := 0; j < n; j++ {
+		l, u := 0.0, math.Inf(+1)
+		if this.Lower != nil {
+			l = this.Lower[j]
+		}
+		if this.Upper != nil {
+			u = this.Upper[j]
+		}
+		if l > u {
+			return &LPResult{Status: LPInfeasible}, nil
+		}
+		switch c := sign * this.Objective[j]; {
+		case c > 0 || c == 0 && !math.IsInf(l, -1):
+			res.X[j] = l
+		case c < 0 || !math.IsInf(u, +1):
+			res.X[j] = u
+		}
+		if math.IsInf(res.X[j], 0) {
+			res.Status = LPUnbounded
+		}
+	}
+	if res.Status == LPUnbounded {
+		return &LPResult{Status: LPUnbounded}, nil
+	}
+	res.Value, _ = this.Objective.Compute(res.X...)
+	return res, nil
+}
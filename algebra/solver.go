@@ -0,0 +1,252 @@
+package algebra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const epsilon = 2.220446049250313e-16
+
+var (
+	ErrNotBracketed   = errors.New("Value f(a) & f(b) have the same sign")
+	ErrZeroDerivative = errors.New("Derivative vanishes during iteration")
+)
+
+// 迭代次数耗尽或迭代值发散时返回的错误
+type ConvergenceError struct {
+	Method     string
+	Iterations int
+}
+
+func (this *ConvergenceError) Error() string {
+	return fmt.Sprintf("%s failed to converge after %d iterations", this.Method, this.Iterations)
+}
+
+// 迭代求解的参数，零值字段使用默认值
+type Options struct {
+	AbsTol  float64         // 绝对误差限，默认1e-12
+	RelTol  float64         // 相对误差限，默认4倍机器精度
+	MaxIter int             // 最大迭代次数，默认100
+	Context context.Context // 可选，用于取消迭代
+}
+
+// 填充默认值后的参数副本
+func (this *Options) normalize() Options {
+	var o Options
+	if this != nil {
+		o = *this
+	}
+	if o.AbsTol <= 0 {
+		o.AbsTol = 1e-12
+	}
+	if o.RelTol <= 0 {
+		o.RelTol = 4 * epsilon
+	}
+	if o.MaxIter <= 0 {
+		o.MaxIter = 100
+	}
+	return o
+}
+
+// 误差限
+func (this *Options) tol(x float64) float64 {
+	return this.AbsTol + this.RelTol*math.Abs(x)
+}
+
+// 检查迭代是否已被取消
+func (this *Options) cancelled() error {
+	if this.Context == nil {
+		return nil
+	}
+	select {
+	case <-this.Context.Done():
+		return this.Context.Err()
+	default:
+		return nil
+	}
+}
+
+// 求根的结果
+type RootResult struct {
+	X          float64 // 近似解
+	Y          float64 // f(X)
+	Iterations int     // 已使用的迭代次数
+	A, B       float64 // 最终区间；开区间方法为最后两次迭代值
+}
+
+// 设置最终区间
+func (this *RootResult) bracket(a, b float64) {
+	if a > b {
+		a, b = b, a
+	}
+	this.A, this.B = a, b
+}
+
+// 布伦特法求区间[a,b]内的解，要求f(a)与f(b)异号
+func Brent(f func(float64) float64, a, b float64, opt *Options) (*RootResult, error) {
+	o := opt.normalize()
+	fa, fb := f(a), f(b)
+	res := &RootResult{}
+	switch {
+	case fa == 0:
+		res.X, res.Y, res.A, res.B = a, 0, a, a
+		return res, nil
+	case fb == 0:
+		res.X, res.Y, res.A, res.B = b, 0, b, b
+		return res, nil
+	case (fa > 0) == (fb > 0):
+		res.bracket(a, b)
+		return res, ErrNotBracketed
+	}
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for res.Iterations = 0; ; res.Iterations++ {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		t := 2*epsilon*math.Abs(b) + o.tol(b)/2
+		m := (c - b) / 2
+		res.X, res.Y = b, fb
+		res.bracket(b, c)
+		if math.Abs(m) <= t || fb == 0 {
+			return res, nil
+		}
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{"Brent", res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		if math.Abs(e) >= t && math.Abs(fa) > math.Abs(fb) {
+			// 割线法或逆二次插值
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(t*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > t {
+			b += d
+		} else if m > 0 {
+			b += t
+		} else {
+			b -= t
+		}
+		fb = f(b)
+	}
+}
+
+// 割线法，以x0、x1为初始的两个迭代点
+func Secant(f func(float64) float64, x0, x1 float64, opt *Options) (*RootResult, error) {
+	o := opt.normalize()
+	y0, y1 := f(x0), f(x1)
+	res := &RootResult{}
+	for res.Iterations = 0; ; res.Iterations++ {
+		res.X, res.Y = x1, y1
+		res.bracket(x0, x1)
+		if y1 == 0 {
+			return res, nil
+		}
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{"Secant", res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		if y1 == y0 {
+			return res, ErrZeroDerivative
+		}
+		d := y1 * (x1 - x0) / (y1 - y0)
+		x0, y0 = x1, y1
+		x1 -= d
+		if math.IsNaN(x1) || math.IsInf(x1, 0) {
+			return res, &ConvergenceError{"Secant", res.Iterations}
+		}
+		y1 = f(x1)
+		if math.Abs(d) <= o.tol(x1) {
+			res.Iterations++
+			res.X, res.Y = x1, y1
+			res.bracket(x0, x1)
+			return res, nil
+		}
+	}
+}
+
+// 牛顿切线法，k为f的导函数，x为迭代起始点
+func Newton(f, k func(float64) float64, x float64, opt *Options) (*RootResult, error) {
+	return householder("Newton", f, func(x, y float64) (float64, bool) {
+		d := k(x)
+		return y / d, d != 0
+	}, x, opt)
+}
+
+// 哈雷法，k为f的导函数，g为f的二阶导函数，x为迭代起始点
+func Halley(f, k, g func(float64) float64, x float64, opt *Options) (*RootResult, error) {
+	return householder("Halley", f, func(x, y float64) (float64, bool) {
+		d := k(x)
+		q := 2*d*d - y*g(x)
+		return 2 * y * d / q, q != 0
+	}, x, opt)
+}
+
+// 单点迭代的公共流程，step返回迭代步长及其是否有效
+func householder(name string, f func(float64) float64, step func(x, y float64) (float64, bool), x float64, opt *Options) (*RootResult, error) {
+	o := opt.normalize()
+	y := f(x)
+	res := &RootResult{A: x, B: x}
+	for res.Iterations = 0; ; res.Iterations++ {
+		res.X, res.Y = x, y
+		if y == 0 {
+			return res, nil
+		}
+		if res.Iterations >= o.MaxIter {
+			return res, &ConvergenceError{name, res.Iterations}
+		}
+		if err := o.cancelled(); err != nil {
+			return res, err
+		}
+		d, ok := step(x, y)
+		if !ok {
+			return res, ErrZeroDerivative
+		}
+		z := x - d
+		if math.IsNaN(z) || math.IsInf(z, 0) {
+			return res, &ConvergenceError{name, res.Iterations}
+		}
+		res.bracket(x, z)
+		x, y = z, f(z)
+		if math.Abs(d) <= o.tol(x) {
+			res.Iterations++
+			res.X, res.Y = x, y
+			return res, nil
+		}
+	}
+}
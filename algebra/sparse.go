@@ -0,0 +1,274 @@
+package algebra
+
+import (
+	"math"
+	"sort"
+)
+
+// 坐标格式（COO）的稀疏矩阵，用于逐个添加非零元后转换为CSR格式
+type COO struct {
+	Rows, Cols int
+	I, J       []int
+	V          []float64
+}
+
+// 创建r行c列的空COO矩阵
+func NewCOO(r, c int) *COO {
+	return &COO{Rows: r, Cols: c}
+}
+
+// 添加元素(i,j)，同一位置多次添加时值相加；下标越界时panic
+func (this *COO) Add(i, j int, v float64) {
+	if i < 0 || i >= this.Rows || j < 0 || j >= this.Cols {
+		panic("index out of range")
+	}
+	this.I = append(this.I, i)
+	this.J = append(this.J, j)
+	this.V = append(this.V, v)
+}
+
+// 转换为CSR格式，合并重复的元素
+func (this *COO) CSR() *CSR {
+	n := len(this.V)
+	idx := make([]int, n)
+	for k := range idx {
+		idx[k] = k
+	}
+	sort.Slice(idx, func(a, b int) bool {
+		p, q := idx[a], idx[b]
+		if this.I[p] != this.I[q] {
+			return this.I[p] < this.I[q]
+		}
+		return this.J[p] < this.J[q]
+	})
+	that := &CSR{Rows: this.Rows, Cols: this.Cols, RowPtr: make([]int, this.Rows+1)}
+	for t, k := range idx {
+		i, j := this.I[k], this.J[k]
+		if t > 0 && i == this.I[idx[t-1]] && j == this.J[idx[t-1]] {
+			that.Val[len(that.Val)-1] += this.V[k]
+			continue
+		}
+		that.ColInd = append(that.ColInd, j)
+		that.Val = append(that.Val, this.V[k])
+		that.RowPtr[i+1]++
+	}
+	for i := 0; i < this.Rows; i++ {
+		that.RowPtr[i+1] += that.RowPtr[i]
+	}
+	return that
+}
+
+// 压缩行格式（CSR）的稀疏矩阵，第i行的非零元为Val[RowPtr[i]:RowPtr[i+1]]，
+// 对应的列号为ColInd中的同一段，且按升序排列
+type CSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColInd     []int
+	Val        []float64
+}
+
+// 转换为CSR格式，忽略零元素
+func (this Matrix) CSR() *CSR {
+	that := &CSR{Rows: this.Rows(), Cols: this.Cols(), RowPtr: make([]int, this.Rows()+1)}
+	for i, row := range this {
+		for j, v := range row {
+			if v != 0 {
+				that.ColInd = append(that.ColInd, j)
+				that.Val = append(that.Val, v)
+			}
+		}
+		that.RowPtr[i+1] = len(that.Val)
+	}
+	return that
+}
+
+// 非零元的个数
+func (this *CSR) NNZ() int {
+	return len(this.Val)
+}
+
+// 元素(i,j)的值
+func (this *CSR) At(i, j int) float64 {
+	l, r := this.RowPtr[i], this.RowPtr[i+1]
+	k := l + sort.SearchInts(this.ColInd[l:r], j)
+	if k < r && this.ColInd[k] == j {
+		return this.Val[k]
+	}
+	return 0
+}
+
+// 转换为稠密矩阵
+func (this *CSR) Dense() Matrix {
+	m := NewMatrix(this.Rows, this.Cols)
+	for i := 0; i < this.Rows; i++ {
+		for k := this.RowPtr[i]; k < this.RowPtr[i+1]; k++ {
+			m[i][this.ColInd[k]] = this.Val[k]
+		}
+	}
+	return m
+}
+
+// 转置
+func (this *CSR) Transpose() *CSR {
+	that := &CSR{
+		Rows: this.Cols, Cols: this.Rows, RowPtr: make([]int, this.Cols+1),
+		ColInd: make([]int, len(this.Val)), Val: make([]float64, len(this.Val)),
+	}
+	for _, j := range this.ColInd {
+		that.RowPtr[j+1]++
+	}
+	for j := 0; j < this.Cols; j++ {
+		that.RowPtr[j+1] += that.RowPtr[j]
+	}
+	next := make([]int, this.Cols)
+	copy(next, that.RowPtr)
+	for i := 0; i < this.Rows; i++ {
+		for k := this.RowPtr[i]; k < this.RowPtr[i+1]; k++ {
+			j := this.ColInd[k]
+			that.ColInd[next[j]] = i
+			that.Val[next[j]] = this.Val[k]
+			next[j]++
+		}
+	}
+	return that
+}
+
+// 对角元
+func (this *CSR) Diagonal() []float64 {
+	n := this.Rows
+	if this.Cols < n {
+		n = this.Cols
+	}
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = this.At(i, i)
+	}
+	return d
+}
+
+// y=A*x，不检查维数
+func (this *CSR) mul(y, x []float64) {
+	for i := 0; i < this.Rows; i++ {
+		var s float64
+		for k := this.RowPtr[i]; k < this.RowPtr[i+1]; k++ {
+			s += this.Val[k] * x[this.ColInd[k]]
+		}
+		y[i] = s
+	}
+}
+
+// 矩阵乘以列向量
+func (this *CSR) MulVec(x []float64) ([]float64, error) {
+	if len(x) != this.Cols {
+		return nil, ErrDimension
+	}
+	y := make([]float64, this.Rows)
+	this.mul(y, x)
+	return y, nil
+}
+
+// 预条件子，Solve求解M*z=r，M为A的某种近似
+type Preconditioner interface {
+	Solve(z, r []float64)
+}
+
+// 雅可比（对角）预条件子
+type Jacobi struct {
+	inv []float64
+}
+
+// 用A的对角元构造雅可比预条件子，对角元为零时返回ErrSingular
+func NewJacobi(A *CSR) (*Jacobi, error) {
+	if A.Rows != A.Cols {
+		return nil, ErrDimension
+	}
+	d := A.Diagonal()
+	for i, e := range d {
+		if e == 0 {
+			return nil, ErrSingular
+		}
+		d[i] = 1 / e
+	}
+	return &Jacobi{d}, nil
+}
+
+func (this *Jacobi) Solve(z, r []float64) {
+	for i, e := range this.inv {
+		z[i] = e * r[i]
+	}
+}
+
+// 零填充不完全LU分解预条件子，L、U与A有相同的非零结构
+type ILU0 struct {
+	lu   *CSR
+	diag []int // 各行对角元在lu.Val中的位置
+}
+
+// 对A做零填充不完全LU分解，对角元缺失或为零时返回ErrSingular
+func NewILU0(A *CSR) (*ILU0, error) {
+	n := A.Rows
+	if A.Cols != n {
+		return nil, ErrDimension
+	}
+	lu := &CSR{
+		Rows: n, Cols: n, RowPtr: A.RowPtr, ColInd: A.ColInd,
+		Val: make([]float64, len(A.Val)),
+	}
+	copy(lu.Val, A.Val)
+	diag := make([]int, n)
+	pos := make([]int, n)
+	for j := range pos {
+		pos[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		l, r := lu.RowPtr[i], lu.RowPtr[i+1]
+		for k := l; k < r; k++ {
+			pos[lu.ColInd[k]] = k
+		}
+		diag[i] = -1
+		for k := l; k < r; k++ {
+			c := lu.ColInd[k]
+			if c >= i {
+				if c == i {
+					diag[i] = k
+				}
+				break
+			}
+			// a[i][c] /= a[c][c]，再消去第i行中c之后的元素
+			lu.Val[k] /= lu.Val[diag[c]]
+			f := lu.Val[k]
+			for t := diag[c] + 1; t < lu.RowPtr[c+1]; t++ {
+				if p := pos[lu.ColInd[t]]; p >= 0 {
+					lu.Val[p] -= f * lu.Val[t]
+				}
+			}
+		}
+		for k := l; k < r; k++ {
+			pos[lu.ColInd[k]] = -1
+		}
+		if diag[i] < 0 || lu.Val[diag[i]] == 0 || math.IsNaN(lu.Val[diag[i]]) {
+			return nil, ErrSingular
+		}
+	}
+	return &ILU0{lu, diag}, nil
+}
+
+func (this *ILU0) Solve(z, r []float64) {
+	a := this.lu
+	n := a.Rows
+	// L的对角元为1
+	for i := 0; i < n; i++ {
+		s := r[i]
+		for k := a.RowPtr[i]; k < this.diag[i]; k++ {
+			s -= a.Val[k] * z[a.ColInd[k]]
+		}
+		z[i] = s
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := z[i]
+		for k := this.diag[i] + 1; k < a.RowPtr[i+1]; k++ {
+			s -= a.Val[k] * z[a.ColInd[k]]
+		}
+		z[i] = s / a.Val[this.diag[i]]
+	}
+}
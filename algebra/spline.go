@@ -0,0 +1,228 @@
+package algebra
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// 分段多项式（样条），第i段定义在[X[i],X[i+1]]上，P[i]以t=x-X[i]为自变量；
+// 超出两端的部分用首尾两段外推
+type Spline struct {
+	X []float64
+	P []Unary
+}
+
+// 检查样本点并计算各区间长度与斜率
+func splineNodes(x, y []float64) (h, d []float64, err error) {
+	l := len(x)
+	if len(y) < l {
+		l = len(y)
+	}
+	if l < 2 {
+		return nil, nil, errors.New("Data-set too small")
+	}
+	h, d = make([]float64, l-1), make([]float64, l-1)
+	for i := 0; i < l-1; i++ {
+		if h[i] = x[i+1] - x[i]; !(h[i] > 0) {
+			return nil, nil, errors.New("Nodes not strictly increasing")
+		}
+		d[i] = (y[i+1] - y[i]) / h[i]
+	}
+	return h, d, nil
+}
+
+// 由各节点处的函数值与导数值构造分段三次埃尔米特插值
+func hermiteSpline(x, y, s, h, d []float64) *Spline {
+	n := len(h)
+	this := &Spline{X: make([]float64, n+1), P: make([]Unary, n)}
+	copy(this.X, x)
+	for i := 0; i < n; i++ {
+		this.P[i] = Unary{
+			y[i],
+			s[i],
+			(3*d[i] - 2*s[i] - s[i+1]) / h[i],
+			(s[i] + s[i+1] - 2*d[i]) / (h[i] * h[i]),
+		}
+	}
+	return this
+}
+
+// 追赶法解三对角方程组，a为下对角线（a[0]不用），b为主对角线，c为上对角线（c[n-1]不用）
+func solveTridiagonal(a, b, c, d []float64) []float64 {
+	n := len(d)
+	q, x := make([]float64, n), make([]float64, n)
+	w := b[0]
+	x[0] = d[0] / w
+	for i := 1; i < n; i++ {
+		q[i-1] = c[i-1] / w
+		w = b[i] - a[i]*q[i-1]
+		x[i] = (d[i] - a[i]*x[i-1]) / w
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= q[i] * x[i+1]
+	}
+	return x
+}
+
+// 三次样条各节点处导数满足的三对角方程组（不含首尾两行）
+func splineSystem(h, d []float64) (a, b, c, r []float64) {
+	n := len(h) + 1
+	a, b, c, r = make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		a[i] = h[i]
+		b[i] = 2 * (h[i-1] + h[i])
+		c[i] = h[i-1]
+		r[i] = 3 * (h[i]*d[i-1] + h[i-1]*d[i])
+	}
+	return
+}
+
+// 自然三次样条，两端二阶导数为零
+func NaturalSpline(x, y []float64) (*Spline, error) {
+	h, d, err := splineNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	n := len(h)
+	a, b, c, r := splineSystem(h, d)
+	b[0], c[0], r[0] = 2, 1, 3*d[0]
+	a[n], b[n], r[n] = 1, 2, 3*d[n-1]
+	return hermiteSpline(x, y, solveTridiagonal(a, b, c, r), h, d), nil
+}
+
+// 固支三次样条，两端一阶导数分别为p、q
+func ClampedSpline(x, y []float64, p, q float64) (*Spline, error) {
+	h, d, err := splineNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	n := len(h)
+	a, b, c, r := splineSystem(h, d)
+	b[0], r[0] = 1, p
+	b[n], r[n] = 1, q
+	return hermiteSpline(x, y, solveTridiagonal(a, b, c, r), h, d), nil
+}
+
+// 非扭结三次样条，首尾两段分别与相邻段为同一个三次多项式；不足四个点时退化为插值多项式
+func NotAKnotSpline(x, y []float64) (*Spline, error) {
+	h, d, err := splineNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	n := len(h)
+	if n < 3 {
+		p, err := NewtonInterpolate(x[:n+1], y[:n+1])
+		if err != nil {
+			return nil, err
+		}
+		this := &Spline{X: make([]float64, n+1), P: make([]Unary, n)}
+		copy(this.X, x)
+		for i := range this.P {
+			this.P[i] = p.Move(-x[i], 0)
+		}
+		return this, nil
+	}
+	a, b, c, r := splineSystem(h, d)
+	b[0], c[0] = h[1], h[0]+h[1]
+	r[0] = ((h[0]+2*(h[0]+h[1]))*h[1]*d[0] + h[0]*h[0]*d[1]) / (h[0] + h[1])
+	a[n], b[n] = h[n-1]+h[n-2], h[n-2]
+	r[n] = (h[n-1]*h[n-1]*d[n-2] + (2*(h[n-2]+h[n-1])+h[n-1])*h[n-2]*d[n-1]) / (h[n-2] + h[n-1])
+	return hermiteSpline(x, y, solveTridiagonal(a, b, c, r), h, d), nil
+}
+
+// 保单调的分段三次埃尔米特插值（PCHIP，Fritsch-Carlson方法）
+func MonotoneSpline(x, y []float64) (*Spline, error) {
+	h, d, err := splineNodes(x, y)
+	if err != nil {
+		return nil, err
+	}
+	n := len(h)
+	s := make([]float64, n+1)
+	if n == 1 {
+		s[0], s[1] = d[0], d[0]
+		return hermiteSpline(x, y, s, h, d), nil
+	}
+	for k := 1; k < n; k++ {
+		if d[k-1]*d[k] > 0 {
+			w1, w2 := 2*h[k]+h[k-1], h[k]+2*h[k-1]
+			s[k] = (w1 + w2) / (w1/d[k-1] + w2/d[k])
+		}
+	}
+	end := func(h0, h1, d0, d1 float64) float64 {
+		t := ((2*h0+h1)*d0 - h0*d1) / (h0 + h1)
+		switch {
+		case math.Signbit(t) != math.Signbit(d0) || d0 == 0:
+			return 0
+		case math.Signbit(d0) != math.Signbit(d1) && math.Abs(t) > math.Abs(3*d0):
+			return 3 * d0
+		}
+		return t
+	}
+	s[0] = end(h[0], h[1], d[0], d[1])
+	s[n] = end(h[n-1], h[n-2], d[n-1], d[n-2])
+	return hermiteSpline(x, y, s, h, d), nil
+}
+
+// 分段的段数
+func (this *Spline) Pieces() int {
+	return len(this.P)
+}
+
+// 以x为自变量的第i段多项式
+func (this *Spline) Piece(i int) Unary {
+	return this.P[i].Move(this.X[i], 0)
+}
+
+// x所在的段
+func (this *Spline) locate(x float64) int {
+	i := sort.SearchFloat64s(this.X, x) - 1
+	if i < 0 {
+		return 0
+	}
+	if i >= len(this.P) {
+		return len(this.P) - 1
+	}
+	return i
+}
+
+// 计算样条在x处的值
+func (this *Spline) Compute(x float64) float64 {
+	i := this.locate(x)
+	return this.P[i].Compute(x - this.X[i])
+}
+
+// 计算样条在x处的一阶导数
+func (this *Spline) Derivative(x float64) float64 {
+	i := this.locate(x)
+	return this.P[i].Reduce().Compute(x - this.X[i])
+}
+
+// 求导后的样条
+func (this *Spline) Reduce() *Spline {
+	that := &Spline{X: this.X, P: make([]Unary, len(this.P))}
+	for i, p := range this.P {
+		that.P[i] = p.Reduce()
+	}
+	return that
+}
+
+// 样条在[a,b]上的定积分
+func (this *Spline) Integral(a, b float64) float64 {
+	if a > b {
+		return -this.Integral(b, a)
+	}
+	var s float64
+	for i, j := this.locate(a), this.locate(b); i <= j; i++ {
+		l, r := this.X[i], this.X[i+1]
+		if i == 0 || a > l {
+			l = a
+		}
+		if i == len(this.P)-1 || b < r {
+			r = b
+		}
+		q := this.P[i].Integral()
+		s += q.Compute(r-this.X[i]) - q.Compute(l-this.X[i])
+	}
+	return s
+}
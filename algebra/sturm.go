@@ -0,0 +1,210 @@
+package algebra
+
+import (
+	"math"
+	"math/big"
+)
+
+// 斯图姆序列：p0=p，p1=p'，p(k+1)=-(p(k-1) mod p(k))；用有理数精确计算，
+// 浮点系数都精确等于某个有理数，因此相距很近的相异根也不会被舍入误差合并；
+// 计数对给定的系数是精确的，但系数本身的舍入误差可能已使重根分裂为相异根或复根。
+// p有重根时最后一项为gcd(p,p')，整个序列都除以它，此时p0恰为p的无平方部分
+type Sturm []RatUnary
+
+// 去掉绝对值不超过tol的高次项系数，全部为零时返回Unary{0}
+func trimUnary(p Unary, tol float64) Unary {
+	for i := len(p) - 1; i >= 0; i-- {
+		if math.Abs(p[i]) > tol {
+			return p[:i+1]
+		}
+	}
+	return Unary{0}
+}
+
+// 多项式系数绝对值的最大值
+func maxAbs(p Unary) float64 {
+	var m float64
+	for _, e := range p {
+		if e = math.Abs(e); e > m {
+			m = e
+		}
+	}
+	return m
+}
+
+// 生成多项式的斯图姆序列，每一项都除以其首项系数的绝对值；系数含无穷或NaN时返回nil
+func (this Unary) Sturm() Sturm {
+	p := this.Rat()
+	if p == nil {
+		return nil
+	}
+	s := Sturm{p.scaled()}
+	if len(p) <= 1 {
+		return s
+	}
+	s = append(s, p.Reduce().scaled())
+	for k := 1; len(s[k]) > 1; k++ {
+		r := s[k-1].Mod(s[k])
+		if r.IsZero() {
+			break
+		}
+		s = append(s, r.scaled().ScalarMul(big.NewRat(-1, 1)))
+	}
+	if g := s[len(s)-1]; len(g) > 1 {
+		for k, p := range s {
+			s[k] = p.Div(g).scaled()
+		}
+	}
+	return s
+}
+
+// 除以首项系数的绝对值，不改变各点处值的符号
+func (this RatUnary) scaled() RatUnary {
+	p := this.trim()
+	if p.IsZero() {
+		return p
+	}
+	return p.ScalarMul(new(big.Rat).Inv(new(big.Rat).Abs(p[len(p)-1])))
+}
+
+// 多项式在x处的值的符号，x可以为正负无穷
+func (this RatUnary) signAt(x float64) int {
+	if math.IsInf(x, 0) {
+		s := this[len(this)-1].Sign()
+		if x < 0 && len(this)%2 == 0 {
+			s = -s
+		}
+		return s
+	}
+	return this.Compute(new(big.Rat).SetFloat64(x)).Sign()
+}
+
+// 序列在x处的符号变化次数，x可以为正负无穷；各项的值都精确计算
+func (this Sturm) Variations(x float64) int {
+	n, last := 0, 0
+	for _, p := range this {
+		s := p.signAt(x)
+		if s == 0 {
+			continue
+		}
+		if last != 0 && s != last {
+			n++
+		}
+		last = s
+	}
+	return n
+}
+
+// 区间(a,b]内不同实根的个数
+func (this Sturm) Count(a, b float64) int {
+	if a > b {
+		a, b = b, a
+	}
+	return this.Variations(a) - this.Variations(b)
+}
+
+// 用二分法将(a,b]内唯一的实根逼近到区间宽度不超过tol，tol<=0时逼近到浮点数精度；
+// p0只有单根，按它在中点处的精确符号二分
+func (this Sturm) Refine(a, b, tol float64) float64 {
+	if a > b {
+		a, b = b, a
+	}
+	p := this[0]
+	l, r := p.signAt(a), p.signAt(b)
+	if r == 0 {
+		return b
+	}
+	for b-a > tol {
+		m := (a + b) / 2
+		if m <= a || m >= b {
+			break
+		}
+		y := p.signAt(m)
+		switch {
+		case y == 0:
+			return m
+		case l != 0 && l != r:
+			// 有符号变化时直接按函数值的符号二分
+			if y == l {
+				a, l = m, y
+			} else {
+				b, r = m, y
+			}
+		case this.Count(a, m) > 0:
+			b, r = m, y
+		default:
+			a, l = m, y
+		}
+	}
+	return (a + b) / 2
+}
+
+// 区间(a,b]内不同实根的个数，重根只计一次
+func (this Unary) CountRoots(a, b float64) int {
+	return this.Sturm().Count(a, b)
+}
+
+// 多项式所有实根所在区间(-B,B)的界B（柯西界）
+func (this Unary) RootBound() float64 {
+	p := trimUnary(this, 0)
+	n := len(p) - 1
+	var m float64
+	for i := 0; i < n; i++ {
+		if t := math.Abs(p[i] / p[n]); t > m {
+			m = t
+		}
+	}
+	return 1 + m
+}
+
+// 将(a,b]内的实根隔离为互不相交的区间，每个区间(l,r]恰好包含一个实根（重根只计一次）
+func (this Unary) IsolateRoots(a, b float64) [][2]float64 {
+	return this.Sturm().isolate(a, b)
+}
+
+// 用序列将(a,b]内的实根隔离为互不相交的区间
+func (this Sturm) isolate(a, b float64) [][2]float64 {
+	if a > b {
+		a, b = b, a
+	}
+	var ans [][2]float64
+	var isolate func(a, b float64, va, vb int)
+	isolate = func(a, b float64, va, vb int) {
+		switch n := va - vb; {
+		case n <= 0:
+			return
+		case n == 1:
+			ans = append(ans, [2]float64{a, b})
+			return
+		}
+		m := (a + b) / 2
+		if m <= a || m >= b {
+			// 无法再分割的根簇
+			ans = append(ans, [2]float64{a, b})
+			return
+		}
+		vm := this.Variations(m)
+		isolate(a, m, va, vm)
+		isolate(m, b, vm, vb)
+	}
+	isolate(a, b, this.Variations(a), this.Variations(b))
+	return ans
+}
+
+// 用斯图姆序列求多项式全部不同的实根，结果按升序排列，每个根逼近到误差不超过tol；
+// 序列的首项是p的无平方部分，重根处也能按符号二分逼近
+func SolveUnarySturm(p Unary, tol float64) []float64 {
+	if len(trimUnary(p, 0)) <= 1 {
+		return nil
+	}
+	s := p.Sturm()
+	if s == nil {
+		return nil
+	}
+	B := p.RootBound()
+	var ans []float64
+	for _, r := range s.isolate(-B, B) {
+		ans = append(ans, s.Refine(r[0], r[1], tol))
+	}
+	return ans
+}
@@ -0,0 +1,45 @@
+package algebra
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSturmCloseRoots(t *testing.T) {
+	for _, d := range []float64{1e-5, 1e-6, 1e-7, 1e-10} {
+		r := []float64{1, 1 + d, 3}
+		p := fromRoots(r...)
+		if n := p.CountRoots(0, 5); n != 3 {
+			t.Errorf("delta %g: CountRoots = %d, want 3", d, n)
+		}
+		if s := p.IsolateRoots(0, 5); len(s) != 3 {
+			t.Errorf("delta %g: IsolateRoots = %v", d, s)
+		}
+		x := SolveUnarySturm(p, 1e-14)
+		if len(x) != 3 {
+			t.Errorf("delta %g: SolveUnarySturm = %v", d, x)
+			continue
+		}
+		// 由于展开后的系数有舍入误差，根只能精确到约eps/d
+		for i := range r {
+			if math.Abs(x[i]-r[i]) > 1e-15/d {
+				t.Errorf("delta %g: SolveUnarySturm = %v", d, x)
+				break
+			}
+		}
+	}
+}
+
+func TestSturmMultipleRoots(t *testing.T) {
+	p := fromRoots(1, 1, 1, 2)
+	if n := p.CountRoots(0, 3); n != 2 {
+		t.Errorf("CountRoots = %d, want 2", n)
+	}
+	if n := p.CountRoots(0, 1); n != 1 {
+		t.Errorf("CountRoots(0,1) = %d, want 1", n)
+	}
+	x := SolveUnarySturm(p, 1e-14)
+	if len(x) != 2 || math.Abs(x[0]-1) > 1e-14 || math.Abs(x[1]-2) > 1e-14 {
+		t.Errorf("SolveUnarySturm = %v", x)
+	}
+}
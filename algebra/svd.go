@@ -0,0 +1,203 @@
+package algebra
+
+import (
+	"math"
+	"sort"
+)
+
+// 奇异值分解的结果，A=U*diag(S)*V'，S降序排列；U为m×n矩阵，V为n×n正交矩阵
+type SVD struct {
+	U Matrix
+	S []float64
+	V Matrix
+}
+
+// 用单边Jacobi旋转法对矩阵进行奇异值分解
+func (this Matrix) SVD() (*SVD, error) {
+	m, n := this.Rows(), this.Cols()
+	if n == 0 {
+		return nil, ErrDimension
+	}
+	for i := range this {
+		if len(this[i]) != n {
+			return nil, ErrDimension
+		}
+	}
+	// 行数不足时补零行，以便得到完整的右奇异向量（含零空间）
+	r := m
+	if r < n {
+		r = n
+	}
+	u := NewMatrix(r, n)
+	for i := range this {
+		copy(u[i], this[i])
+	}
+	v := Identity(n)
+	for sweep := 0; sweep < 60; sweep++ {
+		done := true
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				var a, b, c float64
+				for i := 0; i < r; i++ {
+					a += u[i][p] * u[i][p]
+					b += u[i][q] * u[i][q]
+					c += u[i][p] * u[i][q]
+				}
+				if c == 0 || math.Abs(c) <= 1e-15*math.Sqrt(a*b) {
+					continue
+				}
+				done = false
+				z := (b - a) / (2 * c)
+				t := 1 / (math.Abs(z) + math.Hypot(1, z))
+				if z < 0 {
+					t = -t
+				}
+				cs := 1 / math.Hypot(1, t)
+				sn := cs * t
+				for i := 0; i < r; i++ {
+					x, y := u[i][p], u[i][q]
+					u[i][p], u[i][q] = cs*x-sn*y, sn*x+cs*y
+				}
+				for i := 0; i < n; i++ {
+					x, y := v[i][p], v[i][q]
+					v[i][p], v[i][q] = cs*x-sn*y, sn*x+cs*y
+				}
+			}
+		}
+		if done {
+			break
+		}
+	}
+	s := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < r; i++ {
+			s[j] = math.Hypot(s[j], u[i][j])
+		}
+		if s[j] != 0 {
+			for i := 0; i < r; i++ {
+				u[i][j] /= s[j]
+			}
+		}
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return s[idx[i]] > s[idx[j]] })
+	ans := &SVD{U: NewMatrix(m, n), S: make([]float64, n), V: NewMatrix(n, n)}
+	for k, j := range idx {
+		ans.S[k] = s[j]
+		for i := 0; i < m; i++ {
+			ans.U[i][k] = u[i][j]
+		}
+		for i := 0; i < n; i++ {
+			ans.V[i][k] = v[i][j]
+		}
+	}
+	return ans, nil
+}
+
+// 判定奇异值为零的阈值
+func (this *SVD) tolerance() float64 {
+	l := len(this.U)
+	if len(this.V) > l {
+		l = len(this.V)
+	}
+	return float64(l) * this.S[0] * epsilon
+}
+
+// 矩阵的数值秩
+func (this *SVD) Rank() int {
+	t, r := this.tolerance(), 0
+	for _, e := range this.S {
+		if e > t {
+			r++
+		}
+	}
+	return r
+}
+
+// 基于2-范数的条件数，奇异矩阵返回正无穷
+func (this *SVD) Cond() float64 {
+	if s := this.S[len(this.S)-1]; s > this.tolerance() {
+		return this.S[0] / s
+	}
+	return math.Inf(+1)
+}
+
+// 零空间的一组标准正交基，每一列为一个基向量；列满秩时返回nil
+func (this *SVD) NullSpace() Matrix {
+	r, n := this.Rank(), len(this.S)
+	if r == n {
+		return nil
+	}
+	ns := NewMatrix(n, n-r)
+	for i := 0; i < n; i++ {
+		copy(ns[i], this.V[i][r:])
+	}
+	return ns
+}
+
+// 用伪逆求Ax=b的最小范数最小二乘解
+func (this *SVD) Solve(b []float64) ([]float64, error) {
+	m, n := len(this.U), len(this.S)
+	if len(b) != m {
+		return nil, ErrDimension
+	}
+	r := this.Rank()
+	x := make([]float64, n)
+	for k := 0; k < r; k++ {
+		var t float64
+		for i := 0; i < m; i++ {
+			t += this.U[i][k] * b[i]
+		}
+		t /= this.S[k]
+		for i := 0; i < n; i++ {
+			x[i] += t * this.V[i][k]
+		}
+	}
+	return x, nil
+}
+
+// 线性方程组的最小二乘解及其诊断信息
+type LeastSquares struct {
+	X         []float64 // 最小范数最小二乘解
+	Rank      int       // 系数矩阵的数值秩
+	Residual  float64   // 残差的2-范数||Ax-b||，为零（舍入误差内）时方程组相容
+	NullSpace Matrix    // 系数矩阵零空间的标准正交基（按列），解唯一时为nil
+}
+
+// 方程组是否有唯一解（不论是否相容）
+func (this *LeastSquares) Unique() bool {
+	return this.NullSpace == nil
+}
+
+// 求解Ax=b的最小范数最小二乘解，适用于超定、欠定及秩亏的方程组
+func SolveLeastSquares(A Matrix, b []float64) (*LeastSquares, error) {
+	if A.Rows() != len(b) {
+		return nil, ErrDimension
+	}
+	svd, err := A.SVD()
+	if err != nil {
+		return nil, err
+	}
+	x, err := svd.Solve(b)
+	if err != nil {
+		return nil, err
+	}
+	y, _ := A.MulVec(x)
+	var res float64
+	for i := range y {
+		res = math.Hypot(res, y[i]-b[i])
+	}
+	return &LeastSquares{X: x, Rank: svd.Rank(), Residual: res, NullSpace: svd.NullSpace()}, nil
+}
+
+// 求解任意个数的多元一次方程的最小范数最小二乘解
+func SolveLinearLeastSquares(p ...Linear) (*LeastSquares, error) {
+	A, b, err := LinearMatrix(p...)
+	if err != nil {
+		return nil, err
+	}
+	return SolveLeastSquares(A, b)
+}
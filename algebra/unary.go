@@ -3,6 +3,8 @@ package algebra
 import (
 	"math"
 	"sort"
+
+	"github.com/hydra13142/math/fft"
 )
 
 // 一元非负整次数多项式
@@ -97,6 +99,13 @@ func (this Unary) Mul(that Unary) Unary {
 	return r
 }
 
+// 用FFT计算多项式乘法，适用于项数很多的因式；结果各系数的绝对误差约为
+// 机器精度乘以乘积的最大系数，系数量级相差悬殊（如(1+x)^n）时较小的系数会完全失去精度，
+// 这种情况应使用Mul
+func (this Unary) MulFFT(that Unary) Unary {
+	return fft.Convolve(this, that)
+}
+
 // 多项式相除
 func (this Unary) Div(that Unary) Unary {
 	x, y := len(this), len(that)
@@ -172,15 +181,16 @@ func (this Unary) ScalarMul(k float64) Unary {
 	return r
 }
 
-// n个p相乘
+// n个p相乘，用平方求幂法计算
 func (this Unary) Pow(n uint) Unary {
-	if n == 0 {
-		return Unary{1}
-	}
-	r := make(Unary, len(this)*int(n))
-	copy(r, this)
-	for ; n > 1; n-- {
-		r = r.Mul(this)
+	r, p := Unary{1}, this
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			r = r.Mul(p)
+		}
+		if n > 1 {
+			p = p.Mul(p)
+		}
 	}
 	return r
 }
@@ -243,30 +253,53 @@ func SolveUnary(p Unary) []float64 {
 		case k == 0:
 			s = []float64{-b / (2 * a)}
 		}
+	case 5:
+		s = Ferrari(p[4], p[3], p[2], p[1], p[0])
 	default:
 		q := p.Reduce()
 		n := SolveUnary(q)
+		// p在驻点处的值在舍入误差内为零时，该驻点就是p的重根，与它相邻的单调区间内不会再有别的根
+		c := p.Complex()
+		zero := make([]bool, len(n))
+		for i, x := range n {
+			if math.Abs(p.Compute(x)) <= evalBound(c, complex(x, 0)) {
+				zero[i] = true
+				s = append(s, x)
+			}
+		}
+		// l为驻点个数，最后一个驻点是n[l-1]，不能写成n[l]
 		if l := len(n); l == 0 {
 			if x, e := Monotone(p.Compute, q.Compute); e == nil {
 				s = append(s, x)
 			}
 		} else {
-			if x, e := Tangent(p.Compute, q.Compute, math.Inf(-1), n[0], n[0]-1); e == nil {
-				s = append(s, x)
+			if !zero[0] {
+				if x, e := Tangent(p.Compute, q.Compute, math.Inf(-1), n[0], n[0]-1); e == nil {
+					s = append(s, x)
+				}
 			}
-			for i, l := 0, l-1; i < l; i++ {
+			for i := 0; i < l-1; i++ {
+				if zero[i] || zero[i+1] {
+					continue
+				}
 				if x, e := Region(p.Compute, n[i], n[i+1]); e == nil {
 					s = append(s, x)
 				}
 			}
-			if x, e := Tangent(p.Compute, q.Compute, math.Inf(+1), n[l], n[l]+1); e == nil {
-				s = append(s, x)
+			if !zero[l-1] {
+				if x, e := Tangent(p.Compute, q.Compute, math.Inf(+1), n[l-1], n[l-1]+1); e == nil {
+					s = append(s, x)
+				}
 			}
 		}
 	}
 	if i > 0 {
 		s = append(s, 0)
 	}
+	// 无实根时不能访问s[0]
+	if len(s) == 0 {
+		return nil
+	}
 	sort.Float64s(s)
 	j, t := 1, s[0]
 	for i := 1; i < len(s); i++ {
@@ -0,0 +1,46 @@
+package algebra
+
+import (
+	"math"
+	"testing"
+)
+
+// 二项式系数C(n,k)
+func binomial(n, k int) float64 {
+	c := 1.0
+	for i := 1; i <= k; i++ {
+		c = c * float64(n-k+i) / float64(i)
+	}
+	return c
+}
+
+func TestPowBinomial(t *testing.T) {
+	p := Unary{1, 1}.Pow(200)
+	if len(p) != 201 {
+		t.Fatalf("len = %d, want 201", len(p))
+	}
+	for k, c := range p {
+		if e := binomial(200, k); math.Abs(c-e) > 1e-12*e {
+			t.Errorf("p[%d] = %g, want %g", k, c, e)
+		}
+	}
+}
+
+func TestMulFFT(t *testing.T) {
+	a := Unary{1, 1}.Pow(64)
+	want := a.Mul(a)
+	got := a.MulFFT(a)
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	// FFT的误差相对于最大系数而言很小，但不保证较小系数的相对精度
+	top := maxAbs(want)
+	for k := range want {
+		if math.Abs(got[k]-want[k]) > 1e-12*top {
+			t.Errorf("coefficient %d: FFT %g, schoolbook %g", k, got[k], want[k])
+		}
+		if e := binomial(128, k); math.Abs(want[k]-e) > 1e-12*e {
+			t.Errorf("Mul coefficient %d = %g, want %g", k, want[k], e)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// 快速傅里叶变换及基于它的快速卷积
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// 是否为2的整数次幂
+func isPow2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// 不小于n的最小的2的整数次幂
+func nextPow2(n int) int {
+	m := 1
+	for m < n {
+		m <<= 1
+	}
+	return m
+}
+
+// 原地进行基2迭代FFT，n必须为2的整数次幂；sign为-1时为正变换，为+1时为未归一化的逆变换
+func radix2(x []complex128, sign float64) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	// 旋转因子直接用三角函数计算，避免递推累积误差
+	w := make([]complex128, n/2)
+	for k := range w {
+		s, c := math.Sincos(sign * 2 * math.Pi * float64(k) / float64(n))
+		w[k] = complex(c, s)
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for i := 0; i < n; i += size {
+			for k := 0; k < half; k++ {
+				t := w[k*step] * x[i+k+half]
+				x[i+k+half] = x[i+k] - t
+				x[i+k] += t
+			}
+		}
+	}
+}
+
+// Bluestein算法：把任意长度的DFT化为2的整数次幂长度的循环卷积
+func bluestein(x []complex128, sign float64) {
+	n := len(x)
+	m := nextPow2(2*n - 1)
+	// 啁啾因子exp(sign*i*pi*k^2/n)，k^2对2n取模以保持精度
+	w := make([]complex128, n)
+	for k := range w {
+		t := (k * k) % (2 * n)
+		s, c := math.Sincos(sign * math.Pi * float64(t) / float64(n))
+		w[k] = complex(c, s)
+	}
+	a, b := make([]complex128, m), make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * w[k]
+	}
+	b[0] = cmplx.Conj(w[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(w[k])
+		b[m-k] = b[k]
+	}
+	radix2(a, -1)
+	radix2(b, -1)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, +1)
+	for k := 0; k < n; k++ {
+		x[k] = a[k] * w[k] / complex(float64(m), 0)
+	}
+}
+
+// 原地变换，长度任意
+func transform(x []complex128, sign float64) {
+	switch {
+	case len(x) <= 1:
+	case isPow2(len(x)):
+		radix2(x, sign)
+	default:
+		bluestein(x, sign)
+	}
+}
+
+// 离散傅里叶变换X[k]=Σx[j]*exp(-2πijk/n)，长度为2的整数次幂时用基2算法，否则用Bluestein算法
+func FFT(x []complex128) []complex128 {
+	y := make([]complex128, len(x))
+	copy(y, x)
+	transform(y, -1)
+	return y
+}
+
+// 离散傅里叶逆变换x[j]=Σ X[k]*exp(2πijk/n)/n
+func IFFT(x []complex128) []complex128 {
+	y := make([]complex128, len(x))
+	copy(y, x)
+	transform(y, +1)
+	k := complex(1/float64(len(y)), 0)
+	for i := range y {
+		y[i] *= k
+	}
+	return y
+}
+
+// 实序列的离散傅里叶变换，只返回前n/2+1个系数（其余为它们的共轭）；
+// 长度为偶数时把实序列打包成长度减半的复序列计算
+func RealFFT(x []float64) []complex128 {
+	n := len(x)
+	if n%2 != 0 || n < 2 {
+		y := make([]complex128, n)
+		for i, e := range x {
+			y[i] = complex(e, 0)
+		}
+		transform(y, -1)
+		return y[:n/2+1]
+	}
+	h := n / 2
+	z := make([]complex128, h)
+	for i := range z {
+		z[i] = complex(x[2*i], x[2*i+1])
+	}
+	transform(z, -1)
+	y := make([]complex128, h+1)
+	for k := 0; k <= h; k++ {
+		a, b := z[k%h], cmplx.Conj(z[(h-k)%h])
+		e, o := (a+b)/2, (a-b)/complex(0, 2)
+		s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+		y[k] = e + complex(c, s)*o
+	}
+	return y
+}
+
+// RealFFT的逆变换，X为前n/2+1个系数，n为原实序列的长度
+func InverseRealFFT(X []complex128, n int) []float64 {
+	if n <= 0 {
+		return nil
+	}
+	if n%2 != 0 || n < 2 {
+		y := make([]complex128, n)
+		for k := 0; k < n; k++ {
+			if k <= n/2 {
+				y[k] = X[k]
+			} else {
+				y[k] = cmplx.Conj(X[n-k])
+			}
+		}
+		transform(y, +1)
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = real(y[i]) / float64(n)
+		}
+		return x
+	}
+	h := n / 2
+	z := make([]complex128, h)
+	for k := 0; k < h; k++ {
+		a, b := X[k], cmplx.Conj(X[h-k])
+		s, c := math.Sincos(2 * math.Pi * float64(k) / float64(n))
+		z[k] = (a + b) + complex(0, 1)*complex(c, s)*(a-b)
+	}
+	transform(z, +1)
+	x := make([]float64, n)
+	for i, e := range z {
+		x[2*i] = real(e) / float64(n)
+		x[2*i+1] = imag(e) / float64(n)
+	}
+	return x
+}
+
+// 实序列的线性卷积c[k]=Σa[i]*b[k-i]，长度为len(a)+len(b)-1；
+// 结果的绝对误差约为机器精度乘以系数的量级，较小的系数可能失去相对精度
+func Convolve(a, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	l := len(a) + len(b) - 1
+	m := nextPow2(l)
+	x, y := make([]float64, m), make([]float64, m)
+	copy(x, a)
+	copy(y, b)
+	X, Y := RealFFT(x), RealFFT(y)
+	for i := range X {
+		X[i] *= Y[i]
+	}
+	return InverseRealFFT(X, m)[:l]
+}
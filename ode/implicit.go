@@ -0,0 +1,89 @@
+package ode
+
+import (
+	"math"
+
+	"github.com/hydra13142/math/algebra"
+)
+
+// 用牛顿法求解隐式方程 y - h*b*f(t,y) - r = 0，以p为迭代起点
+func implicit(f Func, t, h, b float64, r, p []float64, sol *Solution) ([]float64, error) {
+	G := func(y []float64) []float64 {
+		d := f(t, y)
+		sol.Evaluations++
+		g := make([]float64, len(y))
+		for i := range g {
+			g[i] = y[i] - h*b*d[i] - r[i]
+		}
+		return g
+	}
+	tol := 1e-10
+	for _, e := range r {
+		tol = math.Max(tol, 1e-12*math.Abs(e))
+	}
+	res, err := algebra.NewtonSystem(G, nil, p, &algebra.Options{AbsTol: tol, MaxIter: 50})
+	if err != nil {
+		return nil, err
+	}
+	return res.X, nil
+}
+
+// 向后欧拉法（一阶隐式），定步长，适用于刚性方程
+func BackwardEuler(f Func, t0 float64, y0 []float64, t1 float64, opt *Options) (*Solution, error) {
+	return bdf(f, t0, y0, t1, opt, 1)
+}
+
+// 二阶向后差分法（BDF2），定步长，首步用向后欧拉法启动，适用于刚性方程
+func BDF2(f Func, t0 float64, y0 []float64, t1 float64, opt *Options) (*Solution, error) {
+	return bdf(f, t0, y0, t1, opt, 2)
+}
+
+// 向后差分法的公共流程，order为阶数（1或2）
+func bdf(f Func, t0 float64, y0 []float64, t1 float64, opt *Options, order int) (*Solution, error) {
+	o := opt.normalize(t0, t1)
+	sol := newSolution(t0, y0)
+	t, y := t0, sol.Y[0]
+	h := math.Copysign(o.Step, t1-t0)
+	fy := f(t, y)
+	sol.Evaluations++
+	var prev []float64
+	for n := 0; (t1-t)*h > 0; n++ {
+		if n >= o.MaxSteps {
+			return sol, ErrMaxSteps
+		}
+		if (t+h-t1)*h > 0 {
+			// 末步步长不同，退回一阶公式
+			h, prev = t1-t, nil
+		}
+		s := t + h
+		if (t1-s)*h <= 0 {
+			s = t1
+		}
+		r, b := make([]float64, len(y)), 1.0
+		if order == 2 && prev != nil {
+			// y(n+1) - 4/3 y(n) + 1/3 y(n-1) = 2/3 h f(t(n+1), y(n+1))
+			for i := range r {
+				r[i] = (4*y[i] - prev[i]) / 3
+			}
+			b = 2. / 3
+		} else {
+			copy(r, y)
+		}
+		// 以显式欧拉步作为迭代起点
+		p := make([]float64, len(y))
+		for i := range p {
+			p[i] = y[i] + h*fy[i]
+		}
+		z, err := implicit(f, s, h, b, r, p, sol)
+		if err != nil {
+			return sol, err
+		}
+		fz := f(s, z)
+		sol.Evaluations++
+		if sol.push(s, z, hermite(t, s, y, z, fy, fz), &o) {
+			return sol, nil
+		}
+		t, prev, y, fy = s, y, z, fz
+	}
+	return sol, nil
+}
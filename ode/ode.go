@@ -0,0 +1,146 @@
+// 常微分方程组dy/dt=f(t,y)的数值解法
+package ode
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/hydra13142/math/algebra"
+)
+
+var (
+	ErrStepSize = errors.New("Step size too small")
+	ErrMaxSteps = errors.New("Too many steps")
+)
+
+// 方程组的右端函数，返回dy/dt
+type Func func(t float64, y []float64) []float64
+
+// 事件函数，其值变号时触发事件
+type EventFunc func(t float64, y []float64) float64
+
+// 求解参数，零值字段使用默认值
+type Options struct {
+	Step     float64     // 定步长方法的步长，自适应方法的初始步长；默认为区间长度的1/100
+	AbsTol   float64     // 自适应方法的绝对误差限，默认1e-8
+	RelTol   float64     // 自适应方法的相对误差限，默认1e-6
+	MaxSteps int         // 最大步数，默认100000
+	Events   []EventFunc // 事件函数
+	Terminal bool        // 为真时在第一个事件处停止积分
+}
+
+// 填充默认值后的参数副本
+func (this *Options) normalize(t0, t1 float64) Options {
+	var o Options
+	if this != nil {
+		o = *this
+	}
+	if o.Step <= 0 {
+		o.Step = math.Abs(t1-t0) / 100
+	}
+	if o.AbsTol <= 0 {
+		o.AbsTol = 1e-8
+	}
+	if o.RelTol <= 0 {
+		o.RelTol = 1e-6
+	}
+	if o.MaxSteps <= 0 {
+		o.MaxSteps = 100000
+	}
+	return o
+}
+
+// 已触发的事件
+type Event struct {
+	Index int       // 事件函数的下标
+	T     float64   // 触发时刻
+	Y     []float64 // 触发时的状态
+}
+
+// 数值解：各步的时刻与状态，以及可在任意时刻求值的稠密输出
+type Solution struct {
+	T           []float64
+	Y           [][]float64
+	Events      []Event
+	Evaluations int // 调用f的次数
+	dense       []func(float64) []float64
+}
+
+// 求t时刻的状态，t超出积分区间时按首尾两步外推
+func (this *Solution) At(t float64) []float64 {
+	n := len(this.dense)
+	if n == 0 {
+		y := make([]float64, len(this.Y[0]))
+		copy(y, this.Y[0])
+		return y
+	}
+	// 积分方向可能为负，按与起点的距离二分查找
+	dir := 1.0
+	if this.T[n] < this.T[0] {
+		dir = -1
+	}
+	i, j := 0, n-1
+	for i < j {
+		m := (i + j + 1) / 2
+		if dir*(t-this.T[m]) >= 0 {
+			i = m
+		} else {
+			j = m - 1
+		}
+	}
+	return this.dense[i](t)
+}
+
+// 记录一步积分并检测事件，返回是否因终止事件而停止
+func (this *Solution) push(t1 float64, y1 []float64, dense func(float64) []float64, o *Options) bool {
+	t0, y0 := this.T[len(this.T)-1], this.Y[len(this.Y)-1]
+	var found []Event
+	for i, g := range o.Events {
+		g0, g1 := g(t0, y0), g(t1, y1)
+		if g0 == 0 || g1 != 0 && (g0 > 0) == (g1 > 0) {
+			continue
+		}
+		r, err := algebra.Brent(func(t float64) float64 { return g(t, dense(t)) }, t0, t1, nil)
+		if err != nil {
+			continue
+		}
+		found = append(found, Event{i, r.X, dense(r.X)})
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return math.Abs(found[i].T-t0) < math.Abs(found[j].T-t0)
+	})
+	stop := o.Terminal && len(found) > 0
+	if stop {
+		found = found[:1]
+		t1, y1 = found[0].T, found[0].Y
+	}
+	this.Events = append(this.Events, found...)
+	this.T = append(this.T, t1)
+	this.Y = append(this.Y, y1)
+	this.dense = append(this.dense, dense)
+	return stop
+}
+
+// 由两端的状态和导数构造三次埃尔米特插值
+func hermite(t0, t1 float64, y0, y1, f0, f1 []float64) func(float64) []float64 {
+	h := t1 - t0
+	return func(t float64) []float64 {
+		s := (t - t0) / h
+		s2, s3 := s*s, s*s*s
+		a, b := 2*s3-3*s2+1, s3-2*s2+s
+		c, d := -2*s3+3*s2, s3-s2
+		y := make([]float64, len(y0))
+		for i := range y {
+			y[i] = a*y0[i] + b*h*f0[i] + c*y1[i] + d*h*f1[i]
+		}
+		return y
+	}
+}
+
+// 创建从(t0,y0)开始的数值解
+func newSolution(t0 float64, y0 []float64) *Solution {
+	y := make([]float64, len(y0))
+	copy(y, y0)
+	return &Solution{T: []float64{t0}, Y: [][]float64{y}}
+}
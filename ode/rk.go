@@ -0,0 +1,150 @@
+package ode
+
+import "math"
+
+// y+h*(c[0]*k[0]+c[1]*k[1]+...)
+func combine(y []float64, h float64, c []float64, k [][]float64) []float64 {
+	z := make([]float64, len(y))
+	for i := range z {
+		var s float64
+		for j, e := range c {
+			if e != 0 {
+				s += e * k[j][i]
+			}
+		}
+		z[i] = y[i] + h*s
+	}
+	return z
+}
+
+// 经典四阶龙格-库塔法，定步长从t0积分到t1，稠密输出为三次埃尔米特插值
+func RK4(f Func, t0 float64, y0 []float64, t1 float64, opt *Options) (*Solution, error) {
+	o := opt.normalize(t0, t1)
+	sol := newSolution(t0, y0)
+	t, y := t0, sol.Y[0]
+	h := math.Copysign(o.Step, t1-t0)
+	k1 := f(t, y)
+	sol.Evaluations++
+	for n := 0; (t1-t)*h > 0; n++ {
+		if n >= o.MaxSteps {
+			return sol, ErrMaxSteps
+		}
+		// 最后一步恰好落在t1上，并吸收累积的舍入误差
+		last := (t+h-t1)*h > -1e-9*h*h
+		if last {
+			h = t1 - t
+		}
+		k2 := f(t+h/2, combine(y, h/2, []float64{1}, [][]float64{k1}))
+		k3 := f(t+h/2, combine(y, h/2, []float64{1}, [][]float64{k2}))
+		k4 := f(t+h, combine(y, h, []float64{1}, [][]float64{k3}))
+		z := combine(y, h, []float64{1. / 6, 1. / 3, 1. / 3, 1. / 6}, [][]float64{k1, k2, k3, k4})
+		s := t + h
+		if last {
+			s = t1
+		}
+		k5 := f(s, z)
+		sol.Evaluations += 4
+		if sol.push(s, z, hermite(t, s, y, z, k1, k5), &o) {
+			return sol, nil
+		}
+		t, y, k1 = s, z, k5
+	}
+	return sol, nil
+}
+
+// Dormand-Prince 5(4)法的系数
+var (
+	dpC = [7]float64{0, 1. / 5, 3. / 10, 4. / 5, 8. / 9, 1, 1}
+	dpA = [7][]float64{
+		nil,
+		{1. / 5},
+		{3. / 40, 9. / 40},
+		{44. / 45, -56. / 15, 32. / 9},
+		{19372. / 6561, -25360. / 2187, 64448. / 6561, -212. / 729},
+		{9017. / 3168, -355. / 33, 46732. / 5247, 49. / 176, -5103. / 18656},
+		{35. / 384, 0, 500. / 1113, 125. / 192, -2187. / 6784, 11. / 84},
+	}
+	dpE = []float64{71. / 57600, 0, -71. / 16695, 71. / 1920, -17253. / 339200, 22. / 525, -1. / 40}
+	dpD = []float64{-12715105075. / 11282082432, 0, 87487479700. / 32700410799, -10690763975. / 1880347072,
+		701980252875. / 199316789632, -1453857185. / 822651844, 69997945. / 29380423}
+)
+
+// Dormand-Prince 5(4)自适应步长龙格-库塔法（RK45），带四阶稠密输出
+func DormandPrince(f Func, t0 float64, y0 []float64, t1 float64, opt *Options) (*Solution, error) {
+	o := opt.normalize(t0, t1)
+	sol := newSolution(t0, y0)
+	t, y := t0, sol.Y[0]
+	h := math.Copysign(o.Step, t1-t0)
+	k := make([][]float64, 7)
+	k[0] = f(t, y)
+	sol.Evaluations++
+	for n := 0; (t1-t)*h > 0; n++ {
+		if n >= o.MaxSteps {
+			return sol, ErrMaxSteps
+		}
+		if math.Abs(h) <= 16*(math.Nextafter(math.Abs(t), math.Inf(+1))-math.Abs(t)) {
+			return sol, ErrStepSize
+		}
+		last := (t+h-t1)*h >= 0
+		if last {
+			h = t1 - t
+		}
+		for i := 1; i < 7; i++ {
+			k[i] = f(t+dpC[i]*h, combine(y, h, dpA[i], k))
+		}
+		sol.Evaluations += 6
+		z := combine(y, h, dpA[6], k)
+		// 误差的加权均方根范数
+		var e float64
+		for i := range y {
+			var d float64
+			for j, c := range dpE {
+				d += c * k[j][i]
+			}
+			s := o.AbsTol + o.RelTol*math.Max(math.Abs(y[i]), math.Abs(z[i]))
+			e += (h * d / s) * (h * d / s)
+		}
+		e = math.Sqrt(e / float64(len(y)))
+		if e > 1 {
+			h *= math.Max(0.2, 0.9*math.Pow(e, -0.2))
+			continue
+		}
+		s := t + h
+		if last {
+			s = t1
+		}
+		if sol.push(s, z, dormandPrinceDense(t, h, y, z, k), &o) {
+			return sol, nil
+		}
+		t, y = s, z
+		k[0] = k[6]
+		if e == 0 {
+			h *= 5
+		} else {
+			h *= math.Min(5, 0.9*math.Pow(e, -0.2))
+		}
+	}
+	return sol, nil
+}
+
+// Dormand-Prince法一步内的稠密输出
+func dormandPrinceDense(t, h float64, y0, y1 []float64, k [][]float64) func(float64) []float64 {
+	n := len(y0)
+	r1, r2, r3, r4 := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	r5 := combine(make([]float64, n), h, dpD, k)
+	for i := 0; i < n; i++ {
+		r1[i] = y1[i] - y0[i]
+		r2[i] = h*k[0][i] - r1[i]
+		r3[i] = r1[i] - h*k[6][i] - r2[i]
+		r4[i] = r5[i]
+	}
+	return func(s float64) []float64 {
+		u := (s - t) / h
+		v := 1 - u
+		z := make([]float64, n)
+		for i := range z {
+			z[i] = y0[i] + u*(r1[i]+v*(r2[i]+u*(r3[i]+v*r4[i])))
+		}
+		return z
+	}
+}
@@ -0,0 +1,27 @@
+package ode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRK4MaxSteps(t *testing.T) {
+	f := func(t float64, y []float64) []float64 {
+		return []float64{y[0]}
+	}
+	sol, err := RK4(f, 0, []float64{1}, 1, &Options{Step: 0.01, MaxSteps: 10})
+	if err != ErrMaxSteps {
+		t.Fatalf("expect ErrMaxSteps, got %v", err)
+	}
+	if n := len(sol.T); sol.T[n-1] >= 1 {
+		t.Errorf("integration reported reaching t=%v", sol.T[n-1])
+	}
+	sol, err = RK4(f, 0, []float64{1}, 1, &Options{Step: 0.01, MaxSteps: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(sol.T)
+	if sol.T[n-1] != 1 || math.Abs(sol.Y[n-1][0]-math.E) > 1e-8 {
+		t.Errorf("got y(%v)=%v, expect e", sol.T[n-1], sol.Y[n-1][0])
+	}
+}
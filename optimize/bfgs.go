@@ -0,0 +1,257 @@
+package optimize
+
+import "math"
+
+// 拟牛顿法的方向更新策略
+type quasiNewton interface {
+	direction(g []float64) []float64 // 返回-H*g
+	update(s, y []float64)           // 用位移s与梯度差y修正
+	reset()
+}
+
+// BFGS：保存完整的逆海森矩阵近似
+type bfgs struct {
+	h [][]float64
+}
+
+func newBFGS(n int) *bfgs {
+	this := &bfgs{h: make([][]float64, n)}
+	for i := range this.h {
+		this.h[i] = make([]float64, n)
+	}
+	this.reset()
+	return this
+}
+
+func (this *bfgs) reset() {
+	for i := range this.h {
+		for j := range this.h[i] {
+			this.h[i][j] = 0
+		}
+		this.h[i][i] = 1
+	}
+}
+
+func (this *bfgs) direction(g []float64) []float64 {
+	d := make([]float64, len(g))
+	for i, row := range this.h {
+		for j, e := range row {
+			d[i] -= e * g[j]
+		}
+	}
+	return d
+}
+
+func (this *bfgs) update(s, y []float64) {
+	n := len(s)
+	sy := dot(s, y)
+	// H' = (I-ρsy')H(I-ρys') + ρss'，ρ=1/(s'y)
+	hy := make([]float64, n)
+	for i, row := range this.h {
+		hy[i] = dot(row, y)
+	}
+	yhy := dot(y, hy)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			this.h[i][j] += ((sy+yhy)*s[i]*s[j])/(sy*sy) - (hy[i]*s[j]+s[i]*hy[j])/sy
+		}
+	}
+}
+
+// L-BFGS：只保存最近m对修正向量
+type lbfgs struct {
+	m    int
+	s, y [][]float64
+}
+
+func (this *lbfgs) reset() {
+	this.s, this.y = nil, nil
+}
+
+func (this *lbfgs) direction(g []float64) []float64 {
+	k := len(this.s)
+	q := make([]float64, len(g))
+	copy(q, g)
+	a := make([]float64, k)
+	for i := k - 1; i >= 0; i-- {
+		a[i] = dot(this.s[i], q) / dot(this.y[i], this.s[i])
+		for j := range q {
+			q[j] -= a[i] * this.y[i][j]
+		}
+	}
+	if k > 0 {
+		r := dot(this.s[k-1], this.y[k-1]) / dot(this.y[k-1], this.y[k-1])
+		for j := range q {
+			q[j] *= r
+		}
+	}
+	for i := 0; i < k; i++ {
+		b := dot(this.y[i], q) / dot(this.y[i], this.s[i])
+		for j := range q {
+			q[j] += (a[i] - b) * this.s[i][j]
+		}
+	}
+	for j := range q {
+		q[j] = -q[j]
+	}
+	return q
+}
+
+func (this *lbfgs) update(s, y []float64) {
+	if len(this.s) == this.m {
+		this.s, this.y = this.s[1:], this.y[1:]
+	}
+	this.s = append(this.s, s)
+	this.y = append(this.y, y)
+}
+
+func dot(a, b []float64) float64 {
+	var s float64
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// BFGS拟牛顿法求多元函数的极小值，grad为梯度函数（为nil时用中心差分近似）；
+// 有边界时采用投影法，位于边界且梯度指向外侧的变量保持不动
+func BFGS(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, opt *Options) (*Result, error) {
+	return minimize(f, grad, x0, opt, func(o *Options) quasiNewton { return newBFGS(len(x0)) })
+}
+
+// 有限内存BFGS法（L-BFGS），适用于变量很多的问题；参数含义同BFGS，修正对个数由Options.Memory指定
+func LBFGS(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, opt *Options) (*Result, error) {
+	return minimize(f, grad, x0, opt, func(o *Options) quasiNewton { return &lbfgs{m: o.Memory} })
+}
+
+// 拟牛顿法的公共流程
+func minimize(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, opt *Options, method func(*Options) quasiNewton) (*Result, error) {
+	n := len(x0)
+	o, err := opt.normalize(n)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Reason: MaxIterations}
+	eval := func(x []float64) float64 {
+		res.Evaluations++
+		return f(x)
+	}
+	if grad == nil {
+		grad = func(x []float64) []float64 { return gradient(f, x) }
+	}
+	x := make([]float64, n)
+	copy(x, x0)
+	o.project(x)
+	fx, g := eval(x), grad(x)
+	q := method(&o)
+	act := make([]bool, n)
+	// 投影梯度：位于边界且梯度指向外侧的分量置零
+	free := func(x, g []float64) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			if !o.active(x, g, i) {
+				p[i] = g[i]
+			}
+		}
+		return p
+	}
+	for ; res.Iterations < o.MaxIter; res.Iterations++ {
+		pg := free(x, g)
+		if normInf(pg) <= o.GradTol {
+			res.Reason = Converged
+			break
+		}
+		// 有效约束集合改变时，原有的曲率信息不再适用
+		changed := false
+		for i := range act {
+			if a := o.active(x, g, i); a != act[i] {
+				act[i], changed = a, true
+			}
+		}
+		if changed {
+			q.reset()
+		}
+		d := q.direction(pg)
+		for i := range d {
+			if act[i] {
+				d[i] = 0
+			}
+		}
+		if dot(d, pg) >= 0 {
+			// 不是下降方向，退回最速下降
+			q.reset()
+			for i := range d {
+				d[i] = -pg[i]
+			}
+		}
+		// 满足弱Wolfe条件的二分线搜索，试探点投影到边界之内
+		t, ft, gt := wolfe(x, fx, pg, d, &o, eval, grad)
+		if t == nil {
+			res.Reason = LineSearch
+			break
+		}
+		s, y := make([]float64, n), make([]float64, n)
+		var dx float64
+		for i := range s {
+			if s[i], y[i] = t[i]-x[i], gt[i]-g[i]; act[i] {
+				y[i] = 0
+			}
+			dx = math.Max(dx, math.Abs(s[i]))
+		}
+		if dot(s, y) > 1e-12*math.Sqrt(dot(s, s)*dot(y, y)) {
+			q.update(s, y)
+		}
+		df := fx - ft
+		x, fx, g = t, ft, gt
+		if dx <= o.XTol*(1+normInf(x)) && df <= o.FTol*(1+math.Abs(fx)) {
+			res.Reason = Converged
+			res.Iterations++
+			break
+		}
+	}
+	res.X, res.F = x, fx
+	return res, nil
+}
+
+// 弱Wolfe条件线搜索：充分下降且方向导数充分增大；失败时返回nil
+func wolfe(x []float64, fx float64, g, d []float64, o *Options, f func([]float64) float64, grad func([]float64) []float64) ([]float64, float64, []float64) {
+	n := len(x)
+	lo, hi, k := 0.0, math.Inf(+1), 1.0
+	gd := dot(g, d)
+	for i := 0; i < 60; i++ {
+		t := make([]float64, n)
+		for j := range t {
+			t[j] = x[j] + k*d[j]
+		}
+		o.project(t)
+		var dd float64
+		for j := range t {
+			dd += g[j] * (t[j] - x[j])
+		}
+		ft := f(t)
+		switch {
+		case !(ft <= fx+1e-4*dd):
+			hi = k
+		default:
+			gt := grad(t)
+			if dot(gt, d) >= 0.9*gd || dd == 0 {
+				return t, ft, gt
+			}
+			lo = k
+		}
+		if math.IsInf(hi, +1) {
+			k *= 2
+		} else {
+			k = (lo + hi) / 2
+		}
+	}
+	if lo > 0 {
+		t := make([]float64, n)
+		for j := range t {
+			t[j] = x[j] + lo*d[j]
+		}
+		o.project(t)
+		return t, f(t), grad(t)
+	}
+	return nil, 0, nil
+}
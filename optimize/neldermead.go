@@ -0,0 +1,114 @@
+package optimize
+
+import (
+	"math"
+	"sort"
+)
+
+// Nelder-Mead单纯形法求多元函数的极小值，不需要梯度；有边界时将试探点投影到边界之内
+func NelderMead(f func([]float64) float64, x0 []float64, opt *Options) (*Result, error) {
+	n := len(x0)
+	o, err := opt.normalize(n)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Reason: MaxIterations}
+	eval := func(x []float64) float64 {
+		res.Evaluations++
+		return f(x)
+	}
+	// 初始单纯形
+	p := make([][]float64, n+1)
+	v := make([]float64, n+1)
+	for i := range p {
+		p[i] = make([]float64, n)
+		copy(p[i], x0)
+		if i > 0 {
+			if p[i][i-1] != 0 {
+				p[i][i-1] *= 1.05
+			} else {
+				p[i][i-1] = 0.00025
+			}
+		}
+		o.project(p[i])
+		v[i] = eval(p[i])
+	}
+	idx := make([]int, n+1)
+	point := func(c []float64, k float64, x []float64) []float64 {
+		y := make([]float64, n)
+		for j := range y {
+			y[j] = c[j] + k*(x[j]-c[j])
+		}
+		return o.project(y)
+	}
+	for ; res.Iterations < o.MaxIter; res.Iterations++ {
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.Slice(idx, func(i, j int) bool { return v[idx[i]] < v[idx[j]] })
+		b, w := idx[0], idx[n]
+		// 收敛判断：函数值与单纯形尺寸均足够小
+		var size float64
+		for _, i := range idx[1:] {
+			for j := range p[i] {
+				size = math.Max(size, math.Abs(p[i][j]-p[b][j]))
+			}
+		}
+		if math.Abs(v[w]-v[b]) <= o.FTol*(1+math.Abs(v[b])) && size <= o.XTol*(1+normInf(p[b])) {
+			res.Reason = Converged
+			break
+		}
+		// 除最差点外各点的重心
+		c := make([]float64, n)
+		for _, i := range idx[:n] {
+			for j := range c {
+				c[j] += p[i][j] / float64(n)
+			}
+		}
+		r := point(c, -1, p[w])
+		fr := eval(r)
+		switch {
+		case fr < v[b]:
+			e := point(c, -2, p[w])
+			if fe := eval(e); fe < fr {
+				p[w], v[w] = e, fe
+			} else {
+				p[w], v[w] = r, fr
+			}
+		case fr < v[idx[n-1]]:
+			p[w], v[w] = r, fr
+		default:
+			var k float64 = 0.5
+			if fr < v[w] {
+				k = -0.5
+			}
+			q := point(c, k, p[w])
+			if fq := eval(q); fq < math.Min(fr, v[w]) {
+				p[w], v[w] = q, fq
+			} else {
+				// 向最优点收缩
+				for _, i := range idx[1:] {
+					p[i] = point(p[b], 0.5, p[i])
+					v[i] = eval(p[i])
+				}
+			}
+		}
+	}
+	b := 0
+	for i := range v {
+		if v[i] < v[b] {
+			b = i
+		}
+	}
+	res.X, res.F = p[b], v[b]
+	return res, nil
+}
+
+// 向量的无穷范数
+func normInf(x []float64) float64 {
+	var s float64
+	for _, e := range x {
+		s = math.Max(s, math.Abs(e))
+	}
+	return s
+}
@@ -0,0 +1,113 @@
+// 一维与多维函数的极小化
+package optimize
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrDimension = errors.New("Mismatched dimension of bounds")
+
+// 迭代终止的原因
+type Reason int
+
+const (
+	Converged     Reason = iota // 满足收敛条件
+	MaxIterations               // 达到最大迭代次数
+	LineSearch                  // 线搜索无法使函数值下降
+)
+
+func (this Reason) String() string {
+	switch this {
+	case Converged:
+		return "Converged"
+	case MaxIterations:
+		return "Max iterations reached"
+	case LineSearch:
+		return "Line search failed"
+	}
+	return "Unknown"
+}
+
+// 极小化的结果
+type Result struct {
+	X           []float64 // 极小值点，一维问题长度为1
+	F           float64   // 极小值
+	Iterations  int       // 已使用的迭代次数
+	Evaluations int       // 调用目标函数的次数（不含梯度）
+	Reason      Reason    // 终止原因
+}
+
+// 极小化的参数，零值字段使用默认值
+type Options struct {
+	XTol    float64   // 自变量的收敛误差限，默认1e-8
+	FTol    float64   // 函数值的收敛误差限，默认1e-12
+	GradTol float64   // 梯度无穷范数的收敛误差限，默认1e-8
+	MaxIter int       // 最大迭代次数，默认1000
+	Memory  int       // L-BFGS保存的修正对个数，默认10
+	Lower   []float64 // 各变量的下界，可为nil；元素可为负无穷
+	Upper   []float64 // 各变量的上界，可为nil；元素可为正无穷
+}
+
+// 填充默认值后的参数副本，并检查边界的维数
+func (this *Options) normalize(n int) (Options, error) {
+	var o Options
+	if this != nil {
+		o = *this
+	}
+	if o.XTol <= 0 {
+		o.XTol = 1e-8
+	}
+	if o.FTol <= 0 {
+		o.FTol = 1e-12
+	}
+	if o.GradTol <= 0 {
+		o.GradTol = 1e-8
+	}
+	if o.MaxIter <= 0 {
+		o.MaxIter = 1000
+	}
+	if o.Memory <= 0 {
+		o.Memory = 10
+	}
+	if o.Lower != nil && len(o.Lower) != n || o.Upper != nil && len(o.Upper) != n {
+		return o, ErrDimension
+	}
+	return o, nil
+}
+
+// 将x投影到边界之内（就地修改），返回x
+func (this *Options) project(x []float64) []float64 {
+	for i := range x {
+		if this.Lower != nil && x[i] < this.Lower[i] {
+			x[i] = this.Lower[i]
+		}
+		if this.Upper != nil && x[i] > this.Upper[i] {
+			x[i] = this.Upper[i]
+		}
+	}
+	return x
+}
+
+// 第i个变量是否位于边界上且梯度g指向边界之外
+func (this *Options) active(x, g []float64, i int) bool {
+	return this.Lower != nil && x[i] <= this.Lower[i] && g[i] > 0 ||
+		this.Upper != nil && x[i] >= this.Upper[i] && g[i] < 0
+}
+
+// 用中心差分近似梯度
+func gradient(f func([]float64) float64, x []float64) []float64 {
+	g := make([]float64, len(x))
+	t := make([]float64, len(x))
+	copy(t, x)
+	for i := range x {
+		h := 6.055454452393343e-06 * math.Max(math.Abs(x[i]), 1) // 机器精度的立方根
+		t[i] = x[i] + h
+		a := f(t)
+		t[i] = x[i] - h
+		b := f(t)
+		t[i] = x[i]
+		g[i] = (a - b) / (2 * h)
+	}
+	return g
+}
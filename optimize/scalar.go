@@ -0,0 +1,144 @@
+package optimize
+
+import (
+	"errors"
+	"math"
+)
+
+const golden = 0.3819660112501051 // (3-sqrt(5))/2
+
+// 一维问题的搜索区间：[a,b]与Lower、Upper给出的边界（长度须为1）的交集
+func (this *Options) interval(a, b float64) (float64, float64, error) {
+	if a > b {
+		a, b = b, a
+	}
+	if this.Lower != nil && this.Lower[0] > a {
+		a = this.Lower[0]
+	}
+	if this.Upper != nil && this.Upper[0] < b {
+		b = this.Upper[0]
+	}
+	if a > b {
+		return a, b, errors.New("Empty search interval")
+	}
+	return a, b, nil
+}
+
+// 黄金分割法求单峰函数f在[a,b]上的极小值；opt中的Lower、Upper长度须为1，会进一步限制搜索区间
+func Golden(f func(float64) float64, a, b float64, opt *Options) (*Result, error) {
+	o, err := opt.normalize(1)
+	if err != nil {
+		return nil, err
+	}
+	if a, b, err = o.interval(a, b); err != nil {
+		return nil, err
+	}
+	c, d := a+golden*(b-a), b-golden*(b-a)
+	fc, fd := f(c), f(d)
+	res := &Result{Evaluations: 2, Reason: MaxIterations}
+	for ; res.Iterations < o.MaxIter; res.Iterations++ {
+		if b-a <= o.XTol*(1+math.Abs(c)) {
+			res.Reason = Converged
+			break
+		}
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = a + golden*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = b - golden*(b-a)
+			fd = f(d)
+		}
+		res.Evaluations++
+	}
+	if fc < fd {
+		res.X, res.F = []float64{c}, fc
+	} else {
+		res.X, res.F = []float64{d}, fd
+	}
+	return res, nil
+}
+
+// 布伦特法（黄金分割与抛物线插值结合）求f在[a,b]上的极小值；边界的处理与Golden相同
+func Brent(f func(float64) float64, a, b float64, opt *Options) (*Result, error) {
+	o, err := opt.normalize(1)
+	if err != nil {
+		return nil, err
+	}
+	if a, b, err = o.interval(a, b); err != nil {
+		return nil, err
+	}
+	x := a + golden*(b-a)
+	w, v := x, x
+	fx := f(x)
+	fw, fv := fx, fx
+	var d, e float64
+	res := &Result{Evaluations: 1, Reason: MaxIterations}
+	for ; res.Iterations < o.MaxIter; res.Iterations++ {
+		m := (a + b) / 2
+		t1 := o.XTol*math.Abs(x) + o.XTol/4
+		t2 := 2 * t1
+		if math.Abs(x-m) <= t2-(b-a)/2 {
+			res.Reason = Converged
+			break
+		}
+		parabola := false
+		if math.Abs(e) > t1 {
+			// 尝试抛物线插值
+			r := (x - w) * (fx - fv)
+			q := (x - v) * (fx - fw)
+			p := (x-v)*q - (x-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			} else {
+				q = -q
+			}
+			if math.Abs(p) < math.Abs(q*e/2) && p > q*(a-x) && p < q*(b-x) {
+				e, d = d, p/q
+				if u := x + d; u-a < t2 || b-u < t2 {
+					d = math.Copysign(t1, m-x)
+				}
+				parabola = true
+			}
+		}
+		if !parabola {
+			if x < m {
+				e = b - x
+			} else {
+				e = a - x
+			}
+			d = golden * e
+		}
+		u := x + d
+		if math.Abs(d) < t1 {
+			u = x + math.Copysign(t1, d)
+		}
+		fu := f(u)
+		res.Evaluations++
+		if fu <= fx {
+			if u < x {
+				b = x
+			} else {
+				a = x
+			}
+			v, w, x = w, x, u
+			fv, fw, fx = fw, fx, fu
+		} else {
+			if u < x {
+				a = u
+			} else {
+				b = u
+			}
+			if fu <= fw || w == x {
+				v, w = w, u
+				fv, fw = fw, fu
+			} else if fu <= fv || v == x || v == w {
+				v, fv = u, fu
+			}
+		}
+	}
+	res.X, res.F = []float64{x}, fx
+	return res, nil
+}